@@ -2,28 +2,32 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+var (
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
 )
 
 func main() {
-	if len(os.Args) != 3 {
-		log.Fatalf("Usage: %s <num-teams> <num-rounds>", os.Args[0])
-	}
-	numTeams, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		log.Fatal(err)
+	flag.Parse()
+	if flag.NArg() != 0 {
+		log.Fatalf("Usage: %s [-league <league-json>]", os.Args[0])
 	}
-	numRounds, err := strconv.Atoi(os.Args[2])
+	league, err := fantasy.LoadLeague(*leagueCfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 	out := csv.NewWriter(os.Stdout)
 	pick := 1
-	for round := 0; round < numRounds; round++ {
-		for i := 0; i < numTeams; i++ {
+	for round := 0; round < league.Rounds; round++ {
+		for i := 0; i < league.Teams; i++ {
 			t := i
 			out.Write([]string{
 				strconv.Itoa(pick),
@@ -10,14 +10,63 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/dbtleonia/fantasy"
 )
 
 var (
-	dummy   = flag.Int("dummy", 10, "number of dummy players to generate for each position")
-	keepers = flag.String("keepers", "", "keepers file")
-	adpDir  = flag.String("adp", "", "directory with ADP values")
+	replacement = flag.Int("replacement", 10, "number of replacement-level players to generate for each position")
+	pool        = flag.Int("pool", 0, "number of players expected to be drafted; 0 means teams * rounds")
+	window      = flag.Int("window", 3, "number of players just past the drafted pool averaged for replacement level")
+	keepers     = flag.String("keepers", "", "keepers file")
+	adpDir      = flag.String("adp", "", "directory with ADP values")
+	schedule    = flag.String("schedule", "", "schedule CSV used to fill in bye weeks")
+	teamProj    = flag.String("team_projections", "", "DST and K projections by team from yards/make_projections, overriding points")
+	scoringFile = flag.String("scoring", "", "scoring JSON file; if set, points are computed from the projected stats instead of taken from the last column")
+	leagueCfg   = flag.String("league", "", "league config JSON file; its scoring is used if -scoring is not set")
+
+	// Stat columns of each position's projections file, between the
+	// team and points columns.
+	statColumns = map[string][]string{
+		"QB":  {"pass_att", "pass_cmp", "pass_yds", "pass_td", "pass_int", "rush_att", "rush_yds", "rush_td", "fum_lost"},
+		"RB":  {"rush_att", "rush_yds", "rush_td", "rec", "rec_yds", "rec_td", "fum_lost"},
+		"WR":  {"rec", "rec_yds", "rec_td", "rush_att", "rush_yds", "rush_td", "fum_lost"},
+		"TE":  {"rec", "rec_yds", "rec_td", "fum_lost"},
+		"K":   {"fg", "fga", "xp"},
+		"DST": {"sack", "def_int", "fum_rec", "fum_forced", "def_td", "safety", "pts_allowed", "yds_allowed"},
+	}
 )
 
+func init() {
+	flag.IntVar(replacement, "dummy", *replacement, "deprecated alias of -replacement")
+}
+
+type player struct {
+	pick      int
+	id        int
+	name      string
+	pos       string
+	team      string
+	bye       int
+	points    float64
+	adpMean   float64
+	adpStddev float64
+}
+
+func (p *player) record() []string {
+	return []string{
+		strconv.Itoa(p.pick),
+		strconv.Itoa(p.id),
+		p.name,
+		p.pos,
+		p.team,
+		strconv.FormatFloat(p.points, 'f', -1, 64),
+		fmt.Sprintf("%.1f", p.adpMean),
+		fmt.Sprintf("%.1f", p.adpStddev),
+		strconv.Itoa(p.bye),
+	}
+}
+
 // TODO: Dedupe with similar function in keeper code.
 func mustReadAll(filename string) [][]string {
 	f, err := os.Open(filename)
@@ -33,6 +82,67 @@ func mustReadAll(filename string) [][]string {
 	return records
 }
 
+// replacementPoints returns the points of a freely available player at
+// the given position: the average of the best window undrafted players
+// there, or fewer if there aren't that many, or 0 if there are none.
+// The first pool players by ADP, breaking ties by points, are assumed
+// drafted, so with no ADP data the drafted pool is the top players by
+// points.  Keepers always count as drafted.
+func replacementPoints(byPoints []*player, pos string, pool, window int) float64 {
+	byADP := append([]*player(nil), byPoints...)
+	sort.SliceStable(byADP, func(i, j int) bool { return byADP[i].adpMean < byADP[j].adpMean })
+	drafted := make(map[*player]bool)
+	for i := 0; i < pool && i < len(byADP); i++ {
+		drafted[byADP[i]] = true
+	}
+
+	total := 0.0
+	n := 0
+	for _, p := range byPoints {
+		if n == window {
+			break
+		}
+		if p.pos == pos && !drafted[p] && p.pick == 0 {
+			total += p.points
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// mergeTeamProjections replaces the points of each team's DST and top
+// kicker with the given projections.  Ties go to the first name.
+func mergeTeamProjections(players map[string]*player, records [][]string) {
+	const (
+		colTeam   = 0
+		colPos    = 1
+		colPoints = 2
+	)
+	for _, record := range records[1:] { // skip header
+		points, err := strconv.ParseFloat(record[colPoints], 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var best *player
+		for _, p := range players {
+			if p.team != record[colTeam] || p.pos != record[colPos] {
+				continue
+			}
+			if best == nil || p.points > best.points || p.points == best.points && p.name < best.name {
+				best = p
+			}
+		}
+		if best == nil {
+			log.Printf("No %s for team %s", record[colPos], record[colTeam])
+			continue
+		}
+		best.points = points
+	}
+}
+
 func main() {
 	flag.Parse()
 	if flag.NArg() != 1 {
@@ -41,6 +151,33 @@ func main() {
 		os.Exit(1)
 	}
 
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *pool == 0 {
+		*pool = league.Teams * league.Rounds
+	}
+
+	var scoring *fantasy.Scoring
+	if *scoringFile != "" {
+		scoring, err = fantasy.ReadScoring(*scoringFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else if *leagueCfg != "" {
+		scoring = &league.Scoring
+	}
+
+	byes := make(map[string]int) // team -> bye week
+	if *schedule != "" {
+		games, err := fantasy.ReadSchedule(*schedule)
+		if err != nil {
+			log.Fatal(err)
+		}
+		byes = fantasy.ByeWeeks(games)
+	}
+
 	keeperPicks := make(map[string]int) // player -> pick
 	if *keepers != "" {
 		k, err := os.Open(*keepers)
@@ -114,8 +251,8 @@ func main() {
 	}
 
 	j := 0
-	players := make(map[string][]string)
-	var problems []string
+	players := make(map[string]*player)
+	var problems, columnProblems []string
 	for _, file := range files {
 		f, err := os.Open(path.Join(projectionsDir, file.Name()))
 		if err != nil {
@@ -129,52 +266,67 @@ func main() {
 			log.Fatal(err)
 		}
 
+		var pos string
+		switch {
+		case strings.Contains(file.Name(), "_DST"):
+			pos = "DST"
+		case strings.Contains(file.Name(), "_K"):
+			pos = "K"
+		case strings.Contains(file.Name(), "_QB"):
+			pos = "QB"
+		case strings.Contains(file.Name(), "_RB"):
+			pos = "RB"
+		case strings.Contains(file.Name(), "_TE"):
+			pos = "TE"
+		case strings.Contains(file.Name(), "_WR"):
+			pos = "WR"
+		default:
+			problems = append(problems, file.Name())
+		}
+
 		const (
-			colName = 0
-			colTeam = 1
+			colName  = 0
+			colTeam  = 1
+			colStats = 2
 		)
 		colPoints := len(records[0]) - 1
+		if scoring != nil && colStats+len(statColumns[pos]) != colPoints {
+			columnProblems = append(columnProblems, fmt.Sprintf("%s: got %d stat columns, want %d", file.Name(), colPoints-colStats, len(statColumns[pos])))
+			continue
+		}
 		for _, record := range records[1:] {
 			if len(record) != len(records[0]) {
 				continue // skip bad records
 			}
 			name := record[colName]
-			points, err := strconv.ParseFloat(record[colPoints], 64)
-			if err != nil {
-				log.Fatal(err)
-			}
-			// TODO: Use a struct rather than an array.
-			if player, ok := players[name]; ok {
-				v, _ := strconv.ParseFloat(player[5], 64)
-				if v > points {
-					continue
+			var points float64
+			if scoring != nil {
+				line := make(fantasy.StatLine)
+				for i, stat := range statColumns[pos] {
+					v, err := strconv.ParseFloat(strings.ReplaceAll(record[colStats+i], ",", ""), 64)
+					if err != nil {
+						log.Fatal(err)
+					}
+					line[stat] = v
+				}
+				points = scoring.Points(pos, line)
+			} else {
+				points, err = strconv.ParseFloat(record[colPoints], 64)
+				if err != nil {
+					log.Fatal(err)
 				}
 			}
-			var pos string
-			switch {
-			case strings.Contains(file.Name(), "_DST"):
-				pos = "DST"
-			case strings.Contains(file.Name(), "_K"):
-				pos = "K"
-			case strings.Contains(file.Name(), "_QB"):
-				pos = "QB"
-			case strings.Contains(file.Name(), "_RB"):
-				pos = "RB"
-			case strings.Contains(file.Name(), "_TE"):
-				pos = "TE"
-			case strings.Contains(file.Name(), "_WR"):
-				pos = "WR"
-			default:
-				problems = append(problems, file.Name())
+			if player, ok := players[name]; ok && player.points > points {
+				continue
 			}
-
 			n := record[colName]
 			if r, ok := projectionsRenames[n]; ok {
 				n = r
 			}
-			t := record[colTeam]
-			if t == "JAC" {
-				t = "JAX"
+			t, err := fantasy.CanonTeam(record[colTeam])
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", file.Name(), err))
+				continue
 			}
 			k := strings.ToUpper(fmt.Sprintf("%s (%s - %s)", n, t, pos))
 			keeperPick, ok := keeperPicks[k]
@@ -189,21 +341,25 @@ func main() {
 				pADP = adp{300.0, 20.0}
 			}
 
-			players[name] = []string{
-				strconv.Itoa(keeperPick),         // pick
-				strconv.Itoa(10000 + j),          // id
-				record[colName],                  // name
-				pos,                              // pos
-				"XXX",                            // team
-				record[colPoints],                // points
-				fmt.Sprintf("%.1f", pADP.mean),   // adp mean
-				fmt.Sprintf("%.1f", pADP.stddev), // adp stddev
+			players[name] = &player{
+				pick:      keeperPick,
+				id:        10000 + j,
+				name:      record[colName],
+				pos:       pos,
+				team:      t,
+				bye:       byes[t],
+				points:    points,
+				adpMean:   pADP.mean,
+				adpStddev: pADP.stddev,
 			}
 			j++
 		}
 	}
+	if len(columnProblems) > 0 {
+		log.Fatalf("Stat columns don't match those expected for -scoring:\n  %s\n", strings.Join(columnProblems, "\n  "))
+	}
 	if len(problems) > 0 {
-		log.Fatalf("Unknown positions:  \n  %s\n", strings.Join(problems, "\n  "))
+		log.Fatalf("Unknown positions or teams:  \n  %s\n", strings.Join(problems, "\n  "))
 	}
 	if len(keeperPicks) > 0 {
 		var ps []string
@@ -221,31 +377,35 @@ func main() {
 		sort.Strings(ps)
 		log.Fatalf("ADP not used for:  \n  %s\n", strings.Join(ps, "\n  "))
 	}
-	var out [][]string
+
+	if *teamProj != "" {
+		mergeTeamProjections(players, mustReadAll(*teamProj))
+	}
+
+	var sorted []*player
 	for _, player := range players {
-		out = append(out, player)
+		sorted = append(sorted, player)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].points > sorted[j].points })
+	var out [][]string
+	for _, player := range sorted {
+		out = append(out, player.record())
 	}
-	sort.Slice(out, func(i, j int) bool {
-		// TODO: Same here, this should use a struct and not re-parse
-		// every time.
-		a, _ := strconv.ParseFloat(out[i][5], 64)
-		b, _ := strconv.ParseFloat(out[j][5], 64)
-		return a > b
-	})
 
-	// Append dummy players.
+	// Append replacement-level players.
 	for j, pos := range []string{"DST", "K", "QB", "RB", "TE", "WR"} {
-		for i := 0; i < *dummy; i++ {
-			out = append(out, []string{
-				"0",                               // pick
-				strconv.Itoa(20000 + 10000*j + i), // id
-				fmt.Sprintf("%sdummy <%s> #%d", pos[:1], pos, i), // name
-				pos,     // pos
-				"XXX",   // team
-				"0",     // points
-				"300.0", // adp mean
-				"20.0",  // adp stddev
-			})
+		points := replacementPoints(sorted, pos, *pool, *window)
+		for i := 0; i < *replacement; i++ {
+			p := &player{
+				id:        20000 + 10000*j + i,
+				name:      fmt.Sprintf("%sreplacement <%s> #%d", pos[:1], pos, i),
+				pos:       pos,
+				team:      "FA",
+				points:    points,
+				adpMean:   300.0,
+				adpStddev: 20.0,
+			}
+			out = append(out, p.record())
 		}
 	}
 	if err = csv.NewWriter(os.Stdout).WriteAll(out); err != nil {
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestReplacementPoints(t *testing.T) {
+	// By points, with ADP breaking the usual order for r2 and w1.
+	byPoints := []*player{
+		{name: "r1", pos: "RB", points: 200, adpMean: 1},
+		{name: "w1", pos: "WR", points: 190, adpMean: 6},
+		{name: "r2", pos: "RB", points: 150, adpMean: 2},
+		{name: "k1", pos: "K", points: 140, pick: 5, adpMean: 90},
+		{name: "w2", pos: "WR", points: 120, adpMean: 3},
+		{name: "r3", pos: "RB", points: 100, adpMean: 4},
+		{name: "w3", pos: "WR", points: 90, adpMean: 5},
+		{name: "r4", pos: "RB", points: 80, adpMean: 7},
+		{name: "k2", pos: "K", points: 10, adpMean: 95},
+	}
+	tests := []struct {
+		name         string
+		pos          string
+		pool, window int
+		want         float64
+	}{
+		{"no pool", "RB", 0, 2, (200 + 150) / 2.0},
+		// r1, r2 and w2 are drafted; w1 is available despite more points.
+		{"by adp", "WR", 3, 2, (190 + 90) / 2.0},
+		{"by adp rb", "RB", 3, 2, (100 + 80) / 2.0},
+		// The window goes past the last undrafted RB.
+		{"window past pool", "RB", 4, 3, 80},
+		// The keeper k1 counts as drafted.
+		{"keeper", "K", 0, 2, 10},
+		{"pool bigger than players", "RB", 100, 3, 0},
+		{"no players at position", "TE", 0, 3, 0},
+	}
+	for _, tt := range tests {
+		if got := replacementPoints(byPoints, tt.pos, tt.pool, tt.window); got != tt.want {
+			t.Errorf("%s: replacementPoints(%s, %d, %d) = %v, want %v", tt.name, tt.pos, tt.pool, tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestMergeTeamProjections(t *testing.T) {
+	for i := 0; i < 10; i++ { // map order varies between runs
+		players := map[string]*player{
+			"Zed":    {name: "Zed", pos: "K", team: "KC", points: 100},
+			"Abe":    {name: "Abe", pos: "K", team: "KC", points: 100},
+			"Chiefs": {name: "Chiefs", pos: "DST", team: "KC", points: 90},
+			"Low":    {name: "Low", pos: "K", team: "KC", points: 50},
+		}
+		mergeTeamProjections(players, [][]string{
+			{"team", "pos", "points"},
+			{"KC", "K", "130"},
+			{"KC", "DST", "110"},
+		})
+		for name, want := range map[string]float64{"Abe": 130, "Zed": 100, "Chiefs": 110, "Low": 50} {
+			if got := players[name].points; got != want {
+				t.Errorf("%s: points = %v, want %v", name, got, want)
+			}
+		}
+	}
+}
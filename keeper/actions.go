@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadActions reads keeper selections from a CSV file with a header and
+// columns manager, player, round-pick, where pick is the overall pick
+// number.  A row with an empty player records that the manager keeps
+// nobody.  The file may cover only some managers; the second result
+// lists the managers present in the order they first appear.  All
+// problems with the file are reported together.
+func ReadActions(c *Constants, filename string) ([]Action, []ManagerID, error) {
+	actions, order, problems, err := readActions(c, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(problems) > 0 {
+		return nil, nil, problems
+	}
+	return actions, order, nil
+}
+
+// ReadValidActions reads keeper selections like ReadActions and checks
+// them with Validate, reporting all problems together.
+func ReadValidActions(c *Constants, filename string) ([]Action, error) {
+	actions, _, problems, err := readActions(c, filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(c, actions); err != nil {
+		for _, p := range err.(Problems) {
+			problems = append(problems, fmt.Sprintf("%s: %s", filename, p))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return actions, nil
+}
+
+// readActions skips rows with problems and returns them separately.
+func readActions(c *Constants, filename string) ([]Action, []ManagerID, Problems, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	mids := make(map[string]ManagerID)
+	for m, manager := range c.Managers {
+		mids[manager.Name] = ManagerID(m)
+	}
+	gids := make(map[string]GridderID)
+	for g, gridder := range c.Gridders {
+		gids[gridder.Name] = GridderID(g)
+	}
+
+	actions := make([]Action, len(c.Managers))
+	var order []ManagerID
+	seen := make(map[ManagerID]bool)
+	var problems Problems
+	for i, record := range records[1:] { // skip header
+		line := i + 2
+		mid, ok := mids[record[0]]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s:%d: no manager with name %q", filename, line, record[0]))
+			continue
+		}
+		if !seen[mid] {
+			seen[mid] = true
+			order = append(order, mid)
+		}
+		if record[1] == "" {
+			continue
+		}
+		gid, ok := gids[record[1]]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s:%d: no gridder with name %q", filename, line, record[1]))
+			continue
+		}
+		pick, err := parseRoundPick(record[2], len(c.Managers))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s", filename, line, err))
+			continue
+		}
+		actions[mid] = append(actions[mid], &Keep{pick, gid})
+	}
+	return actions, order, problems, nil
+}
+
+// parseRoundPick parses a pick like "3-27" and returns the overall pick
+// numbered from 0.
+func parseRoundPick(s string, numManagers int) (int, error) {
+	dash := strings.Index(s, "-")
+	if dash < 0 {
+		return 0, fmt.Errorf("bad pick %q", s)
+	}
+	round, err := strconv.Atoi(s[:dash])
+	if err != nil {
+		return 0, fmt.Errorf("bad pick %q", s)
+	}
+	pick, err := strconv.Atoi(s[dash+1:])
+	if err != nil {
+		return 0, fmt.Errorf("bad pick %q", s)
+	}
+	if pick < 1 || (pick-1)/numManagers != round-1 {
+		return 0, fmt.Errorf("pick %q is not in round %d", s, round)
+	}
+	return pick - 1, nil
+}
+
+// Pins returns the actions of the given managers for IBROptions.Pinned.
+func Pins(actions []Action, mids []ManagerID) map[ManagerID]Action {
+	pinned := make(map[ManagerID]Action)
+	for _, mid := range mids {
+		pinned[mid] = actions[mid]
+	}
+	return pinned
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"sort"
+)
+
+// Gini returns the Gini coefficient of the utilities: 0 when all are
+// equal, approaching 1 when one manager has everything.
+func Gini(utils []float64) float64 {
+	n := len(utils)
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), utils...)
+	sort.Float64s(sorted)
+	var sum, weighted float64
+	for i, u := range sorted {
+		sum += u
+		weighted += float64(i+1) * u
+	}
+	if sum == 0 {
+		return 0
+	}
+	return 2*weighted/(float64(n)*sum) - float64(n+1)/float64(n)
+}
+
+// RosterValues returns the total value of each manager's roster before
+// keepers are chosen.
+func RosterValues(c *Constants) []float64 {
+	result := make([]float64, len(c.Managers))
+	for m, manager := range c.Managers {
+		for _, gid := range manager.GIDs {
+			result[m] += c.Gridders[gid].Value
+		}
+	}
+	return result
+}
+
+// TopAdvantage returns how much the mean utility of the k managers with
+// the most valuable rosters exceeds the mean utility of all managers.
+func TopAdvantage(c *Constants, utils []float64, k int) float64 {
+	rv := RosterValues(c)
+	mids := make([]int, len(utils))
+	for i := range mids {
+		mids[i] = i
+	}
+	sort.SliceStable(mids, func(i, j int) bool { return rv[mids[i]] > rv[mids[j]] })
+	if k > len(mids) {
+		k = len(mids)
+	}
+	if k == 0 {
+		return 0
+	}
+	var top, all float64
+	for i, mid := range mids {
+		if i < k {
+			top += utils[mid]
+		}
+		all += utils[mid]
+	}
+	return top/float64(k) - all/float64(len(utils))
+}
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		utils []float64
+		want  float64
+	}{
+		{[]float64{5, 5, 5, 5}, 0},
+		{[]float64{0, 0, 0, 4}, 0.75},
+		{[]float64{1, 2, 3, 4}, 0.25},
+		{[]float64{4, 3, 2, 1}, 0.25},
+		{nil, 0},
+		{[]float64{0, 0}, 0},
+	}
+	for _, test := range tests {
+		if got := Gini(test.utils); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("Gini(%v) = %v; want %v", test.utils, got, test.want)
+		}
+	}
+}
+
+func TestTopAdvantage(t *testing.T) {
+	// B has the more valuable roster.
+	c := testConstants(2, 3, 1,
+		[]float64{50, 90},
+		[]ManagerID{0, 1},
+		[]int{3, 3})
+	if got, want := TopAdvantage(c, []float64{100, 140}, 1), 20.0; got != want {
+		t.Errorf("TopAdvantage = %v; want %v", got, want)
+	}
+	if got := TopAdvantage(c, []float64{100, 140}, 2); got != 0 {
+		t.Errorf("TopAdvantage(all) = %v; want 0", got)
+	}
+}
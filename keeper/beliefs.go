@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+// WithBeliefs returns a copy of the constants in which each manager
+// chooses responses using their own gridder values, beliefs[mid], while
+// utilities are still reported under our values.  A nil entry means the
+// manager shares our values.  Beliefs are ignored by a Simulator.
+// Beliefs copy any Future values, so call WithDynasty afterwards to
+// compute them under each manager's values.
+func (c *Constants) WithBeliefs(beliefs [][]float64) *Constants {
+	base := *c
+	base.beliefs = nil
+	result := base
+	result.beliefs = make([]*Constants, len(c.Managers))
+	for m, values := range beliefs {
+		if values != nil {
+			result.beliefs[m] = base.WithValues(values)
+		}
+	}
+	return &result
+}
+
+// believed returns the constants manager mid uses to choose responses.
+func (c *Constants) believed(mid ManagerID) *Constants {
+	if c.Simulator == nil && c.beliefs != nil && c.beliefs[mid] != nil {
+		return c.beliefs[mid]
+	}
+	return c
+}
+
+// ReadBeliefs reads each manager's values from a CSV file with a header
+// and columns manager, player, value.  A listed manager values players
+// not in the file as we do.  Managers not in the file share our values.
+func ReadBeliefs(c *Constants, filename string) ([][]float64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	mids := make(map[string]ManagerID)
+	for m, manager := range c.Managers {
+		mids[manager.Name] = ManagerID(m)
+	}
+	gids := make(map[string]GridderID)
+	for g, gridder := range c.Gridders {
+		gids[gridder.Name] = GridderID(g)
+	}
+
+	beliefs := make([][]float64, len(c.Managers))
+	var problems Problems
+	for i, record := range records[1:] { // skip header
+		line := i + 2
+		mid, ok := mids[record[0]]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s:%d: no manager with name %q", filename, line, record[0]))
+			continue
+		}
+		gid, ok := gids[record[1]]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s:%d: no gridder with name %q", filename, line, record[1]))
+			continue
+		}
+		v, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s", filename, line, err))
+			continue
+		}
+		if beliefs[mid] == nil {
+			beliefs[mid] = c.Values()
+		}
+		beliefs[mid][gid] = v
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return beliefs, nil
+}
+
+// NoisyBeliefs returns beliefs for every manager but skip, each our
+// values times a normal factor with mean 1 and stddev frac, truncated at
+// zero.
+func NoisyBeliefs(c *Constants, skip ManagerID, frac float64, rng *rand.Rand) [][]float64 {
+	beliefs := make([][]float64, len(c.Managers))
+	for m := range beliefs {
+		if ManagerID(m) == skip {
+			continue
+		}
+		beliefs[m] = c.Values()
+		for g := range beliefs[m] {
+			beliefs[m][g] *= math.Max(0, 1+rng.NormFloat64()*frac)
+		}
+	}
+	return beliefs
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWithBeliefs(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	// B thinks b is worthless, so doesn't keep b, and A can draft b.
+	beliefs := make([][]float64, 2)
+	beliefs[1] = c.Values()
+	beliefs[1][1] = 0
+	bc := c.WithBeliefs(beliefs)
+
+	result := IteratedBestResponse(bc, &IBROptions{})
+	if !result.Converged || !result.Equilibrium {
+		t.Errorf("Converged = %v, Equilibrium = %v; want both", result.Converged, result.Equilibrium)
+	}
+	last := result.Profiles[len(result.Profiles)-1]
+	want := []Action{{{Pick: 4, GID: 0}}, {}}
+	if !equalProfiles(last, want) {
+		t.Errorf("last profile = %v; want %v", last, want)
+	}
+
+	// Utilities are under our values: A keeps a (100) and drafts b (90)
+	// with pick 0 and e (30) with pick 3.
+	if got, want := UtilityAll(bc, last)[0], 220.0; got != want {
+		t.Errorf("A's utility = %v; want %v", got, want)
+	}
+
+	// Beliefs carry over to trades.
+	traded, err := bc.WithTrade([]Swap{{2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traded.believed(1).PickOwner(2) != 0 {
+		t.Errorf("B's beliefs don't include the trade")
+	}
+
+	// A Simulator doesn't use values, so it ignores beliefs.
+	sc := *bc
+	sc.Simulator = &Simulator{}
+	if sc.believed(1) != &sc {
+		t.Errorf("with a Simulator, B chooses responses with beliefs")
+	}
+}
+
+func TestNoisyBeliefs(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40},
+		[]ManagerID{0, 1, -1, -1},
+		[]int{3, 3, 0, 0})
+	beliefs := NoisyBeliefs(c, 0, 5, rand.New(rand.NewSource(1)))
+	if beliefs[0] != nil {
+		t.Errorf("got beliefs %v for the skipped manager, want nil", beliefs[0])
+	}
+	for g, v := range beliefs[1] {
+		if v < 0 {
+			t.Errorf("gridder %d: got negative value %v", g, v)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	dataDir   = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dir := *dataDir
+	if dir == "" {
+		dir = league.Dir()
+	}
+
+	consts, err := keeper.ReadConstants(dir, league, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filenames := flag.Args()
+	if len(filenames) == 0 {
+		filenames = []string{
+			path.Join(dir, "out", "keeper-ideal.csv"),
+			path.Join(dir, "managers", "keeper-selections.csv"),
+		}
+	}
+	ok := true
+	for _, filename := range filenames {
+		if _, err := keeper.ReadValidActions(consts, filename); err != nil {
+			fmt.Println(err)
+			ok = false
+			continue
+		}
+		fmt.Printf("%s: OK\n", filename)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
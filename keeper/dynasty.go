@@ -0,0 +1,193 @@
+package keeper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Aging gives the factor by which a player's value changes from one
+// season to the next, by position and age.
+type Aging map[string]map[int]float64
+
+// Factor returns the aging factor for a player of the given position
+// and age; unknown positions and ages don't change value.
+func (a Aging) Factor(pos string, age int) float64 {
+	if f, ok := a[pos][age]; ok {
+		return f
+	}
+	return 1
+}
+
+// DefaultAging is a rough aging curve: values grow slightly until a
+// position's peak age and then decline.
+func DefaultAging() Aging {
+	curves := []struct {
+		pos     string
+		peak    int
+		decline float64
+	}{
+		{"QB", 30, 0.07},
+		{"RB", 25, 0.15},
+		{"WR", 27, 0.10},
+		{"TE", 28, 0.10},
+		{"K", 33, 0.03},
+	}
+	a := make(Aging)
+	for _, c := range curves {
+		a[c.pos] = make(map[int]float64)
+		for age := 20; age <= 40; age++ {
+			if age < c.peak {
+				a[c.pos][age] = 1.03
+			} else {
+				a[c.pos][age] = 1 - c.decline
+			}
+		}
+	}
+	return a
+}
+
+// ReadAging reads an aging curve from a CSV file with a header and
+// columns pos, age, factor.
+func ReadAging(filename string) (Aging, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	a := make(Aging)
+	for _, record := range records[1:] { // skip header
+		age, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err)
+		}
+		factor, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err)
+		}
+		if a[record[0]] == nil {
+			a[record[0]] = make(map[int]float64)
+		}
+		a[record[0]][age] = factor
+	}
+	return a, nil
+}
+
+// ReadAges reads gridder ages from a CSV file with a header and columns
+// player, age.  Gridders not in the file have age 0, meaning unknown.
+func ReadAges(c *Constants, filename string) ([]int, error) {
+	ages, err := readPerGridder(c, filename, make([]float64, len(c.Gridders)))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, len(ages))
+	for i, a := range ages {
+		result[i] = int(a)
+	}
+	return result, nil
+}
+
+// Position returns the primary position from a gridder name in the
+// format "Name (Team - Pos)", eg WR for "X (Was - WR,TE)".
+func Position(name string) string {
+	i := strings.LastIndex(name, " - ")
+	if i < 0 {
+		return ""
+	}
+	pos := strings.TrimSuffix(name[i+len(" - "):], ")")
+	return strings.Split(pos, ",")[0]
+}
+
+type DynastyOptions struct {
+	Horizon  int     // seasons including this one
+	Discount float64 // per season
+	Ages     []int   // per gridder; 0 means unknown, so no aging
+	Aging    Aging
+	Rules    []*Rule // keeper rules for future eligibility
+}
+
+// WithDynasty returns a copy of the constants where each owned
+// gridder's Future holds the discounted surplus of keeping them in
+// later seasons.  Each later season the gridder is kept at their keeper
+// round if their projected value exceeds the cost of that round, and
+// otherwise released.  Future keeper rounds come from the rules,
+// assuming the gridder is kept at the round of this season's pick and
+// not dropped.  The cost of a round is the value of a typical gridder
+// taken in the middle of it this season.  Values in later seasons are
+// this season's value aged by the curve.
+func (c *Constants) WithDynasty(opts *DynastyOptions) *Constants {
+	numManagers := len(c.Managers)
+	numRounds := len(c.picks) / numManagers
+	cost := make([]float64, numRounds+1) // by round from 1
+	for r := 1; r <= numRounds; r++ {
+		i := (r-1)*numManagers + numManagers/2
+		if i < len(c.gidsByValue) {
+			cost[r] = c.Gridders[c.gidsByValue[i]].Value
+		}
+	}
+
+	gridders := make([]*Gridder, len(c.Gridders))
+	for i, g := range c.Gridders {
+		gridder := *g
+		gridders[i] = &gridder
+		if g.MID == -1 || g.Round == 0 {
+			continue
+		}
+		pos := Position(g.Name)
+		values := make([]float64, opts.Horizon) // by season from now
+		values[0] = g.Value
+		for t := 1; t < opts.Horizon; t++ {
+			values[t] = values[t-1]
+			if opts.Ages[i] > 0 {
+				values[t] *= opts.Aging.Factor(pos, opts.Ages[i]+t-1)
+			}
+		}
+		gridder.Future = make([]float64, g.Round)
+		for r := 1; r <= g.Round; r++ {
+			h := &History{
+				Position:   pos,
+				DraftRound: r,
+				Kept:       append([]bool{true}, g.Kept...),
+			}
+			gridder.Future[r-1] = opts.Discount * futureSurplus(opts, values, cost, h, 1)
+		}
+	}
+
+	result := *c
+	result.Gridders = gridders
+	if c.beliefs != nil {
+		result.beliefs = make([]*Constants, len(c.beliefs))
+		for m, b := range c.beliefs {
+			if b != nil {
+				result.beliefs[m] = b.WithDynasty(opts)
+			}
+		}
+	}
+	return &result
+}
+
+// futureSurplus returns the best discounted surplus from season t on,
+// given the history as of the end of season t-1.
+func futureSurplus(opts *DynastyOptions, values, cost []float64, h *History, t int) float64 {
+	if t >= opts.Horizon {
+		return 0
+	}
+	round, _ := KeeperRound(opts.Rules, h)
+	if round == 0 || round >= len(cost) {
+		return 0
+	}
+	next := &History{
+		Position:   h.Position,
+		DraftRound: round,
+		Kept:       append([]bool{true}, h.Kept...),
+	}
+	keep := values[t] - cost[round] + opts.Discount*futureSurplus(opts, values, cost, next, t+1)
+	return math.Max(0, keep)
+}
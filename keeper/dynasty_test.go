@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestWithDynasty(t *testing.T) {
+	rules, err := CompileRules(fantasy.DefaultKeeperRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Round costs are the values of gridders b, d and f: 60, 40, 20.
+	c := testConstants(2, 3, 1,
+		[]float64{100, 60, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	c.Gridders[1].Kept = []bool{true, true}
+	opts := &DynastyOptions{
+		Horizon:  3,
+		Discount: 0.5,
+		Ages:     make([]int, len(c.Gridders)),
+		Aging:    DefaultAging(),
+		Rules:    rules,
+	}
+	d := c.WithDynasty(opts)
+
+	// Kept in round 3, a is a round 2 keeper next season (100 - 40) and
+	// a round 1 keeper the season after (100 - 60).  Kept in round 2,
+	// only next season counts, and kept in round 1 a is unkeepable.
+	if got, want := d.Gridders[0].Future, []float64{0, 0.5 * 40, 0.5 * (60 + 0.5*40)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("a: Future = %v; want %v", got, want)
+	}
+	// b would be kept a third straight season, so has no future.
+	if got, want := d.Gridders[1].Future, []float64{0, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("b: Future = %v; want %v", got, want)
+	}
+	if c.Gridders[0].Future != nil {
+		t.Errorf("original constants modified")
+	}
+
+	// Keeping a in round 3 is now worth the future value as well.
+	u := UtilityAll(d, []Action{{{Pick: 4, GID: 0}}, nil})
+	base := UtilityAll(c, []Action{{{Pick: 4, GID: 0}}, nil})
+	if got, want := u[0]-base[0], 40.0; got != want {
+		t.Errorf("utility gain = %v; want %v", got, want)
+	}
+
+	opts.Horizon = 1
+	if got := c.WithDynasty(opts).Gridders[0].Future; !reflect.DeepEqual(got, []float64{0, 0, 0}) {
+		t.Errorf("horizon 1: Future = %v; want zeros", got)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	for name, want := range map[string]string{
+		"Joe Smith (Buf - QB)":        "QB",
+		"Pat Jones (Was - WR,TE)":     "WR",
+		"Ja'Marr Chase-Jr (Cin - WR)": "WR",
+		"Bears (Chi - DEF)":           "DEF",
+		"nobody":                      "",
+	} {
+		if got := Position(name); got != want {
+			t.Errorf("Position(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
+
+func TestWithDynastyBeliefs(t *testing.T) {
+	rules, err := CompileRules(fantasy.DefaultKeeperRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testConstants(2, 3, 1,
+		[]float64{100, 60, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	opts := &DynastyOptions{
+		Horizon:  3,
+		Discount: 0.5,
+		Ages:     make([]int, len(c.Gridders)),
+		Aging:    DefaultAging(),
+		Rules:    rules,
+	}
+	// B thinks a is worth much less than we do.
+	beliefs := make([][]float64, 2)
+	beliefs[1] = c.Values()
+	beliefs[1][0] = 45
+	d := c.WithBeliefs(beliefs).WithDynasty(opts)
+
+	if got, want := d.Gridders[0].Future, c.WithDynasty(opts).Gridders[0].Future; !reflect.DeepEqual(got, want) {
+		t.Errorf("our Future = %v; want %v", got, want)
+	}
+	want := c.WithValues(beliefs[1]).WithDynasty(opts).Gridders[0].Future
+	if got := d.believed(1).Gridders[0].Future; !reflect.DeepEqual(got, want) {
+		t.Errorf("B's Future = %v; want %v", got, want)
+	}
+	if reflect.DeepEqual(want, d.Gridders[0].Future) {
+		t.Errorf("B's Future = our Future = %v; want them to differ", want)
+	}
+	if got := d.believed(0).Gridders[0].Future; !reflect.DeepEqual(got, d.Gridders[0].Future) {
+		t.Errorf("A's Future = %v; want ours, %v", got, d.Gridders[0].Future)
+	}
+}
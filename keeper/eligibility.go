@@ -0,0 +1,209 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+// History is what the keeper rules know about a rostered player.
+// Index n-1 of Kept and Dropped is n seasons ago.
+type History struct {
+	Position   string // primary position, eg DEF
+	DraftRound int    // last season; 0 if undrafted
+	Kept       []bool
+	Dropped    []bool
+}
+
+func (h *History) kept(n int) bool    { return n <= len(h.Kept) && h.Kept[n-1] }
+func (h *History) dropped(n int) bool { return n <= len(h.Dropped) && h.Dropped[n-1] }
+
+type term struct {
+	neg  bool
+	kind string // K, D, UNDRAFTED, POS or R
+	n    int
+	op   string
+	pos  string
+}
+
+func (t *term) eval(h *History) bool {
+	var v bool
+	switch t.kind {
+	case "K":
+		v = h.kept(t.n)
+	case "D":
+		v = h.dropped(t.n)
+	case "UNDRAFTED":
+		v = h.DraftRound == 0
+	case "POS":
+		v = h.Position == t.pos
+	case "R":
+		r := h.DraftRound
+		switch t.op {
+		case "=":
+			v = r == t.n
+		case "!=":
+			v = r != t.n
+		case "<":
+			v = r < t.n
+		case "<=":
+			v = r <= t.n
+		case ">":
+			v = r > t.n
+		case ">=":
+			v = r >= t.n
+		}
+		v = v && r > 0
+	}
+	return v != t.neg
+}
+
+func parseTerm(s string) (*term, error) {
+	t := &term{}
+	if strings.HasPrefix(s, "!") {
+		t.neg = true
+		s = s[1:]
+	}
+	switch {
+	case s == "UNDRAFTED":
+		t.kind = s
+		return t, nil
+	case strings.HasPrefix(s, "POS="):
+		t.kind = "POS"
+		t.pos = s[len("POS="):]
+		if t.pos == "" {
+			return nil, fmt.Errorf("missing position in %q", s)
+		}
+		return t, nil
+	case strings.HasPrefix(s, "R"):
+		t.kind = "R"
+		rest := s[1:]
+		for _, op := range []string{"<=", ">=", "!=", "=", "<", ">"} {
+			if strings.HasPrefix(rest, op) {
+				t.op = op
+				rest = rest[len(op):]
+				break
+			}
+		}
+		if t.op == "" {
+			return nil, fmt.Errorf("missing comparison in %q", s)
+		}
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			return nil, fmt.Errorf("bad round in %q", s)
+		}
+		t.n = n
+		return t, nil
+	case strings.HasPrefix(s, "K"), strings.HasPrefix(s, "D"):
+		t.kind = s[:1]
+		n, err := strconv.Atoi(s[1:])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("bad season in %q", s)
+		}
+		t.n = n
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown term %q", s)
+}
+
+// Rule is a compiled fantasy.KeeperRule.
+type Rule struct {
+	terms  []*term
+	na     bool
+	fixed  int // if rel is false
+	rel    bool
+	offset int
+	max    int
+	reason string
+}
+
+// CompileRules parses the When and Round strings of each rule.
+func CompileRules(rules []fantasy.KeeperRule) ([]*Rule, error) {
+	var result []*Rule
+	for i, kr := range rules {
+		r := &Rule{max: kr.Max, reason: kr.Reason}
+		for _, s := range strings.Fields(kr.When) {
+			t, err := parseTerm(s)
+			if err != nil {
+				return nil, fmt.Errorf("keeper rule %d: %s", i+1, err)
+			}
+			r.terms = append(r.terms, t)
+		}
+		var err error
+		switch round := kr.Round; {
+		case round == "n/a":
+			r.na = true
+		case strings.HasPrefix(round, "R+"):
+			r.rel = true
+			r.offset, err = strconv.Atoi(round[2:])
+		case strings.HasPrefix(round, "R-"):
+			r.rel = true
+			r.offset, err = strconv.Atoi(round[2:])
+			r.offset = -r.offset
+		case round == "R":
+			r.rel = true
+		default:
+			r.fixed, err = strconv.Atoi(round)
+			if err == nil && r.fixed < 1 {
+				err = fmt.Errorf("round must be positive")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("keeper rule %d: bad round %q", i+1, kr.Round)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// KeeperRound applies the first matching rule to h.  It returns round 0
+// if the player is unkeepable, including when no rule matches or a
+// relative round is used for an undrafted player.
+func KeeperRound(rules []*Rule, h *History) (int, string) {
+	for _, r := range rules {
+		match := true
+		for _, t := range r.terms {
+			if !t.eval(h) {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if r.na {
+			return 0, r.reason
+		}
+		round := r.fixed
+		if r.rel {
+			if h.DraftRound == 0 {
+				return 0, r.reason
+			}
+			round = h.DraftRound + r.offset
+		}
+		if r.max > 0 && round > r.max {
+			round = r.max
+		}
+		if round < 1 {
+			return 0, r.reason
+		}
+		return round, r.reason
+	}
+	return 0, "no matching rule"
+}
+
+// Seasons returns how many past seasons of keeps and drops the rules
+// refer to.
+func Seasons(rules []*Rule) int {
+	n := 1 // always need last season's draft
+	for _, r := range rules {
+		for _, t := range r.terms {
+			if (t.kind == "K" || t.kind == "D") && t.n > n {
+				n = t.n
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestKeeperRoundDefaultRules(t *testing.T) {
+	rules, err := CompileRules(fantasy.DefaultKeeperRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, n := true, false
+	tests := []struct {
+		desc       string
+		pos        string
+		round      int
+		kept       []bool
+		dropped    []bool
+		wantRound  int
+		wantReason string
+	}{
+		{"drafted, not kept", "WR", 5, nil, nil, 5, ""},
+		{"drafted round 1, not kept", "RB", 1, nil, nil, 1, ""},
+		{"kept once", "WR", 5, []bool{y}, nil, 4, "kept"},
+		{"kept twice", "WR", 5, []bool{y, y}, nil, 4, "kept"},
+		{"kept in round 1", "RB", 1, []bool{y}, nil, 0, "kept round 1"},
+		{"kept 3 years", "QB", 5, []bool{y, y, y}, nil, 0, "kept 3 years non-D"},
+		{"kept 3 years DEF", "DEF", 5, []bool{y, y, y}, nil, 4, "kept"},
+		{"kept 3 years DEF round 1", "DEF", 1, []bool{y, y, y}, nil, 0, "kept round 1"},
+		{"kept 3 years, dropped 2 ago", "QB", 5, []bool{y, y, y}, []bool{n, y}, 4, "kept"},
+		{"kept 3 years, dropped 3 ago", "QB", 5, []bool{y, y, y}, []bool{n, n, y}, 4, "kept"},
+		{"kept 3 years, dropped last season", "QB", 5, []bool{y, y, y}, []bool{y}, 7, "dropped/saquon"},
+		{"kept 3 years DEF, dropped", "DEF", 5, []bool{y, y, y}, []bool{y}, 7, "dropped"},
+		{"undrafted", "TE", 0, nil, nil, 9, "undrafted"},
+		{"undrafted, dropped", "TE", 0, nil, []bool{y}, 11, "undrafted/dropped"},
+		{"undrafted, dropped 2 ago", "TE", 0, nil, []bool{n, y}, 9, "undrafted"},
+		{"undrafted, kept 3 years", "TE", 0, []bool{n, y, y}, nil, 9, "undrafted"},
+		{"dropped", "WR", 5, nil, []bool{y}, 7, "dropped"},
+		{"dropped, capped", "WR", 15, nil, []bool{y}, 16, "dropped"},
+		{"dropped at cap", "WR", 16, nil, []bool{y}, 16, "dropped"},
+		{"dropped round 1", "RB", 1, nil, []bool{y}, 3, "dropped/saquon"},
+		{"dropped after keep", "WR", 5, []bool{y}, []bool{y}, 7, "dropped"},
+		{"dropped 2 ago", "WR", 5, nil, []bool{n, y}, 5, ""},
+	}
+	for _, test := range tests {
+		h := &History{Position: test.pos, DraftRound: test.round, Kept: test.kept, Dropped: test.dropped}
+		round, reason := KeeperRound(rules, h)
+		if round != test.wantRound || reason != test.wantReason {
+			t.Errorf("%s: got (%d, %q), want (%d, %q)", test.desc, round, reason, test.wantRound, test.wantReason)
+		}
+	}
+}
+
+func TestKeeperRoundCustomRules(t *testing.T) {
+	rules, err := CompileRules([]fantasy.KeeperRule{
+		{When: "R<=2", Round: "n/a", Reason: "early"},
+		{When: "!R!=3", Round: "R-3", Reason: "third"},
+		{When: "R>=10 !POS=K", Round: "R+5", Max: 12, Reason: "late"},
+		{When: "K2", Round: "R-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc       string
+		h          History
+		wantRound  int
+		wantReason string
+	}{
+		{"early", History{DraftRound: 2}, 0, "early"},
+		{"below round 1", History{DraftRound: 3}, 0, "third"},
+		{"capped", History{Position: "WR", DraftRound: 10}, 12, "late"},
+		{"kicker skips late rule", History{Position: "K", DraftRound: 10, Kept: []bool{false, true}}, 9, ""},
+		{"no match", History{Position: "WR", DraftRound: 5}, 0, "no matching rule"},
+		{"negated R matches undrafted", History{Kept: []bool{false, true}}, 0, "third"},
+	}
+	for _, test := range tests {
+		round, reason := KeeperRound(rules, &test.h)
+		if round != test.wantRound || reason != test.wantReason {
+			t.Errorf("%s: got (%d, %q), want (%d, %q)", test.desc, round, reason, test.wantRound, test.wantReason)
+		}
+	}
+	if got := Seasons(rules); got != 2 {
+		t.Errorf("Seasons = %d, want 2", got)
+	}
+}
+
+func TestCompileRulesErrors(t *testing.T) {
+	for _, r := range []fantasy.KeeperRule{
+		{When: "K0", Round: "R"},
+		{When: "Kx", Round: "R"},
+		{When: "R", Round: "R"},
+		{When: "R>x", Round: "R"},
+		{When: "POS=", Round: "R"},
+		{When: "KEPT", Round: "R"},
+		{When: "K1", Round: "R*2"},
+		{When: "K1", Round: "0"},
+		{When: "K1", Round: ""},
+	} {
+		if _, err := CompileRules([]fantasy.KeeperRule{r}); err == nil {
+			t.Errorf("CompileRules(%+v) succeeded, want error", r)
+		}
+	}
+}
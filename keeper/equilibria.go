@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Options returns every action available to a manager: each set of
+// gridders they may keep, assigned to picks in every way that best
+// responses consider.  Unlike AllResponses, which keeps only the best
+// assignment of each set, the result doesn't depend on the other
+// managers' actions.  Unless the latest picks suffice (see
+// latestPicksSuffice), every assignment to allowed picks is included.
+func Options(c *Constants, mid ManagerID) []Action {
+	var result []Action
+	c.keepSets(mid, func(gids []GridderID) {
+		c.assignments(gids, func(a Action) {
+			result = append(result, append(Action(nil), a...))
+		})
+	})
+	return result
+}
+
+// EnumerateEquilibria checks every profile of actions from Options and
+// returns the pure Nash equilibria.  Pinned managers play only their pinned action
+// and are not required to be best responding.  It returns an error
+// without checking anything if there are more than limit profiles.
+func EnumerateEquilibria(c *Constants, pinned map[ManagerID]Action, limit int) ([][]Action, error) {
+	options := make([][]Action, len(c.Managers))
+	total := 1
+	for m := range c.Managers {
+		mid := ManagerID(m)
+		if a, ok := pinned[mid]; ok {
+			options[m] = []Action{a}
+		} else {
+			options[m] = Options(c, mid)
+		}
+		total *= len(options[m])
+		if total > limit {
+			return nil, fmt.Errorf("more than %d profiles", limit)
+		}
+	}
+
+	var result [][]Action
+	index := make([]int, len(c.Managers))
+	for {
+		profile := make([]Action, len(c.Managers))
+		for m, i := range index {
+			profile[m] = options[m][i]
+		}
+		if isEquilibrium(c, profile, pinned) {
+			result = append(result, profile)
+		}
+
+		// Advance the mixed-radix counter.
+		m := 0
+		for ; m < len(index); m++ {
+			index[m]++
+			if index[m] < len(options[m]) {
+				break
+			}
+			index[m] = 0
+		}
+		if m == len(index) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// TopResponses returns the k best responses of a manager to the other
+// managers' actions, best first.  Each set of gridders appears once,
+// with its best assignment among those in Options.
+func TopResponses(c *Constants, mid ManagerID, actions []Action, k int) []*ActionUtility {
+	all := AllResponses(c, mid, actions)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Utility > all[j].Utility })
+	if len(all) > k {
+		all = all[:k]
+	}
+	return all
+}
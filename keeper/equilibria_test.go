@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestEnumerateEquilibria(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	got, err := EnumerateEquilibria(c, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Action{{{Pick: 4, GID: 0}}, {{Pick: 5, GID: 1}}}
+	if len(got) != 1 || !equalProfiles(got[0], want) {
+		t.Errorf("EnumerateEquilibria = %v; want [%v]", got, want)
+	}
+
+	// A pinned to keeping nobody.
+	got, err = EnumerateEquilibria(c, map[ManagerID]Action{0: {}}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Action{{}, {{Pick: 5, GID: 1}}}
+	if len(got) != 1 || !equalProfiles(got[0], want) {
+		t.Errorf("EnumerateEquilibria(pinned) = %v; want [%v]", got, want)
+	}
+
+	if _, err := EnumerateEquilibria(c, nil, 3); err == nil {
+		t.Errorf("EnumerateEquilibria over limit succeeded; want error")
+	}
+}
+
+func TestTopResponses(t *testing.T) {
+	c := testConstants(2, 3, 2,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, 0, -1, -1, -1, -1, -1},
+		[]int{3, 3, 3, 0, 0, 0, 0, 0})
+	top := TopResponses(c, 0, []Action{nil, nil}, 3)
+	if len(top) != 3 {
+		t.Fatalf("got %d responses; want 3", len(top))
+	}
+	for i := 1; i < len(top); i++ {
+		if top[i].Utility > top[i-1].Utility {
+			t.Errorf("responses not sorted: %v before %v", top[i-1].Utility, top[i].Utility)
+		}
+	}
+	if all := AllResponses(c, 0, []Action{nil, nil}); top[0].Utility != bestUtility(all) {
+		t.Errorf("top utility = %v; want %v", top[0].Utility, bestUtility(all))
+	}
+}
+
+func bestUtility(all []*ActionUtility) float64 {
+	best := all[0].Utility
+	for _, au := range all {
+		if au.Utility > best {
+			best = au.Utility
+		}
+	}
+	return best
+}
+
+func TestOptions(t *testing.T) {
+	// A keeps up to 2 of a and c.  The pair has two assignments to
+	// picks, and Options lists both even though a best response would
+	// only use one.
+	c := testConstants(2, 3, 2,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, 0, -1, -1, -1, -1, -1},
+		[]int{3, 3, 3, 0, 0, 0, 0, 0})
+	var got []string
+	for _, a := range Options(c, 0) {
+		got = append(got, fmt.Sprint(a))
+	}
+	want := []string{"[]", "[0@4]", "[2@4]", "[0@4 2@3]", "[0@3 2@4]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Options = %q; want %q", got, want)
+	}
+	if n := len(AllResponses(c, 0, make([]Action, 2))); n != 4 {
+		t.Errorf("len(AllResponses) = %d; want 4", n)
+	}
+
+	// With Future values, an earlier pick may be better, so every
+	// allowed pick is used: A's picks are 4, 3 and 0.
+	c.Gridders[0].Future = []float64{0, 0, 10}
+	got = nil
+	for _, a := range Options(c, 0) {
+		got = append(got, fmt.Sprint(a))
+	}
+	want = []string{
+		"[]",
+		"[0@4]", "[0@3]", "[0@0]",
+		"[2@4]", "[2@3]", "[2@0]",
+		"[0@4 2@3]", "[0@4 2@0]", "[0@3 2@4]", "[0@3 2@0]", "[0@0 2@4]", "[0@0 2@3]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("with Future: Options = %q; want %q", got, want)
+	}
+}
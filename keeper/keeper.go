@@ -3,13 +3,13 @@ package keeper
 import (
 	"encoding/csv"
 	"fmt"
-	"log"
 	"os"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/dbtleonia/fantasy"
 	"gonum.org/v1/gonum/stat/combin"
 )
 
@@ -25,6 +25,23 @@ type Gridder struct {
 	// Allowed Picks in descending order. Does not include Picks
 	// acquired via trade, which are not allowed to be used for keeping.
 	Picks []int
+
+	// Kept[n-1] is whether the gridder was kept n seasons ago.
+	Kept []bool
+
+	// Future is the discounted value of future seasons if kept this
+	// season, indexed by the round of the keeper pick.  Nil means no
+	// future value.
+	Future []float64
+}
+
+// keepValue returns the value of keeping the gridder with a pick.
+func (c *Constants) keepValue(gid GridderID, pick int) float64 {
+	g := c.Gridders[gid]
+	if round := pick / len(c.Managers); round < len(g.Future) {
+		return g.Value + g.Future[round]
+	}
+	return g.Value
 }
 
 type byGridderValue struct {
@@ -58,12 +75,21 @@ type Constants struct {
 	Managers    []*Manager
 	Gridders    []*Gridder
 	picks       []ManagerID // includes picks acquired via trade
+	viaTrade    []bool
+	maxKeepers  int
 	gidsByValue []GridderID
 	combos      [][]int
 
+	// If set, utilities come from simulated drafts instead of summing
+	// gridder values.
+	Simulator *Simulator
+
+	// If set, beliefs[mid] is the constants manager mid uses to choose
+	// responses; nil entries mean our own.  See WithBeliefs.
+	beliefs []*Constants
+
 	// Only when doing reveal.
 	// TODO: Possibly move into a separate type.
-	// TODO: Validate actions on input.
 	Ideal  []Action
 	Actual []Action
 }
@@ -73,6 +99,10 @@ type Keep struct {
 	GID  GridderID
 }
 
+func (k *Keep) String() string {
+	return fmt.Sprintf("%d@%d", k.GID, k.Pick)
+}
+
 type Action []*Keep
 
 func (a Action) findPick(pick int) (GridderID, bool) {
@@ -84,6 +114,29 @@ func (a Action) findPick(pick int) (GridderID, bool) {
 	return -1, false
 }
 
+// Equal returns whether both actions keep the same gridders with the
+// same picks, in any order.
+func (a Action) Equal(b Action) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, k := range a {
+		if gid, ok := b.findPick(k.Pick); !ok || gid != k.GID {
+			return false
+		}
+	}
+	return true
+}
+
+func equalProfiles(a, b []Action) bool {
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (a Action) HasGID(gid GridderID) bool {
 	for _, k := range a {
 		if k.GID == gid {
@@ -93,9 +146,10 @@ func (a Action) HasGID(gid GridderID) bool {
 	return false
 }
 
-func newConstants(gridders []*Gridder, managers []*Manager, picks []ManagerID, picksViaTrade []bool, ideal, actual []Action) *Constants {
+// setAllowedPicks sets the Picks of each gridder.
+func setAllowedPicks(gridders []*Gridder, numManagers int, picks []ManagerID, picksViaTrade []bool) {
 	// Index gridder picks in descending order.
-	managerPicks := make([][]int, len(managers))
+	managerPicks := make([][]int, numManagers)
 	for j := len(picks) - 1; j >= 0; j-- {
 		if picksViaTrade[j] {
 			continue
@@ -104,14 +158,19 @@ func newConstants(gridders []*Gridder, managers []*Manager, picks []ManagerID, p
 		managerPicks[mid] = append(managerPicks[mid], j)
 	}
 	for _, gridder := range gridders {
+		gridder.Picks = nil
 		if gridder.MID >= 0 {
 			for _, pick := range managerPicks[gridder.MID] {
-				if pick/len(managers) < gridder.Round {
+				if pick/numManagers < gridder.Round {
 					gridder.Picks = append(gridder.Picks, pick)
 				}
 			}
 		}
 	}
+}
+
+func newConstants(gridders []*Gridder, managers []*Manager, picks []ManagerID, picksViaTrade []bool, maxKeepers int, ideal, actual []Action) *Constants {
+	setAllowedPicks(gridders, len(managers), picks, picksViaTrade)
 
 	gidsByValue := make([]GridderID, len(gridders))
 	for i, _ := range gridders {
@@ -119,31 +178,125 @@ func newConstants(gridders []*Gridder, managers []*Manager, picks []ManagerID, p
 	}
 	sort.Sort(byGridderValue{gidsByValue, gridders})
 
-	const maxKeepers = 3
 	numRounds := len(picks) / len(managers)
 	var combos [][]int
 	for k := 0; k <= maxKeepers; k++ {
 		combos = append(combos, combin.Combinations(numRounds, k)...)
 	}
 
-	return &Constants{managers, gridders, picks, gidsByValue, combos, ideal, actual}
+	return &Constants{
+		Managers:    managers,
+		Gridders:    gridders,
+		picks:       picks,
+		viaTrade:    picksViaTrade,
+		maxKeepers:  maxKeepers,
+		gidsByValue: gidsByValue,
+		combos:      combos,
+		Ideal:       ideal,
+		Actual:      actual,
+	}
+}
+
+type IBROptions struct {
+	MaxRounds int // 0 means 20
+
+	// Sequential updates each manager's action in turn, so later
+	// managers respond to earlier managers' new actions (Gauss-Seidel).
+	// Otherwise all managers respond to the previous round at once.
+	Sequential bool
+
+	// Pinned managers always play the given action, eg keepers they
+	// have already announced.  Only the other managers respond.
+	Pinned map[ManagerID]Action
+
+	// Start is the initial profile, eg a solution of a similar game.
+	// Nil means nobody keeps anyone but the pinned managers.
+	Start []Action
+}
+
+type IBRResult struct {
+	Profiles [][]Action // one per round, not including the start
+
+	Converged   bool // the last profile is a fixed point
+	CycleLength int  // if not converged, > 0 when the profiles cycle
+	Equilibrium bool // the last profile is a pure Nash equilibrium
 }
 
-func IteratedBestResponse(consts *Constants) [][]Action {
-	// TODO: Don't hardcode length.
-	profiles := [][]Action{
-		{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+// IteratedBestResponse repeatedly replaces each manager's action with a
+// best response, starting from opts.Start, until it reaches a fixed
+// point, detects a cycle or runs out of rounds.
+func IteratedBestResponse(consts *Constants, opts *IBROptions) *IBRResult {
+	maxRounds := opts.MaxRounds
+	if maxRounds == 0 {
+		maxRounds = 20
 	}
-	for i := 0; i < 20; i++ {
+	result := &IBRResult{}
+	start := make([]Action, len(consts.Managers))
+	copy(start, opts.Start)
+	for mid, a := range opts.Pinned {
+		start[mid] = a
+	}
+	profiles := [][]Action{start}
+	for i := 0; i < maxRounds; i++ {
 		prevProfile := profiles[len(profiles)-1]
 		profile := make([]Action, len(consts.Managers))
+		if opts.Sequential {
+			copy(profile, prevProfile)
+		}
 		for m := 0; m < len(consts.Managers); m++ {
-			profile[m] = bestResponse(consts, ManagerID(m), prevProfile)
+			if a, ok := opts.Pinned[ManagerID(m)]; ok {
+				profile[m] = a
+			} else if opts.Sequential {
+				profile[m] = bestResponse(consts, ManagerID(m), profile)
+			} else {
+				profile[m] = bestResponse(consts, ManagerID(m), prevProfile)
+			}
 		}
 		profiles = append(profiles, profile)
+
+		for j := len(profiles) - 2; j >= 0; j-- {
+			if equalProfiles(profiles[j], profile) {
+				if n := len(profiles) - 1 - j; n == 1 {
+					result.Converged = true
+				} else {
+					result.CycleLength = n
+				}
+				break
+			}
+		}
+		if result.Converged || result.CycleLength > 0 {
+			break
+		}
 	}
+	result.Profiles = profiles[1:]
+	result.Equilibrium = isEquilibrium(consts, profiles[len(profiles)-1], opts.Pinned)
+	return result
+}
+
+// IsEquilibrium returns whether no manager can improve their utility by
+// changing only their own action.
+func IsEquilibrium(c *Constants, actions []Action) bool {
+	return isEquilibrium(c, actions, nil)
+}
 
-	return profiles[1:]
+// isEquilibrium is like IsEquilibrium but skips pinned managers.  Each
+// manager's utility is under their own beliefs.
+func isEquilibrium(c *Constants, actions []Action, pinned map[ManagerID]Action) bool {
+	const epsilon = 1e-9
+	for m := range c.Managers {
+		mid := ManagerID(m)
+		if _, ok := pinned[mid]; ok {
+			continue
+		}
+		bc := c.believed(mid)
+		u := utilityOne(bc, actions, mid)
+		for _, au := range AllResponses(bc, mid, actions) {
+			if au.Utility > u+epsilon {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 type ActionUtility struct {
@@ -163,37 +316,94 @@ func AllResponses(c *Constants, mid ManagerID, actions []Action) []*ActionUtilit
 		}
 	}
 
+	c.keepSets(mid, func(gids []GridderID) {
+		if au := bestAssignment(c, mid, gids, newActions); au != nil {
+			result = append(result, au)
+		}
+	})
+	return result
+}
+
+// keepSets calls f with each set of gridders that manager mid may keep.
+func (c *Constants) keepSets(mid ManagerID, f func([]GridderID)) {
 next_combo:
 	for _, combo := range c.combos {
-		response := Action{}
+		var gids []GridderID
 		for _, index := range combo {
 			if index >= len(c.Managers[mid].GIDs) {
 				continue next_combo
 			}
-			gid := c.Managers[mid].GIDs[index]
+			gids = append(gids, c.Managers[mid].GIDs[index])
+		}
+		f(gids)
+	}
+}
 
-			allowedPick := -1
-			for _, pick := range c.Gridders[gid].Picks {
-				if _, ok := response.findPick(pick); !ok {
-					allowedPick = pick
-					break
-				}
-			}
-			if allowedPick == -1 {
-				continue next_combo
+// latestPicksSuffice returns whether a best assignment of n gridders
+// always uses each gridder's latest n allowed picks.  That holds when
+// utility sums values, since keeping with a later pick frees an earlier
+// one, but not with a Simulator or Future values, which can make
+// utility non-monotone in the pick.
+func (c *Constants) latestPicksSuffice() bool {
+	if c.Simulator != nil {
+		return false
+	}
+	for _, g := range c.Gridders {
+		if g.Future != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// assignments calls f with every assignment of the gridders to distinct
+// picks.  If latestPicksSuffice, each gridder's pick is chosen only from
+// the first len(gids) of its allowed picks.  The action passed to f is
+// reused between calls.
+func (c *Constants) assignments(gids []GridderID, f func(Action)) {
+	latest := c.latestPicksSuffice()
+	response := make(Action, 0, len(gids))
+	var assign func(i int)
+	assign = func(i int) {
+		if i == len(gids) {
+			f(response)
+			return
+		}
+		picks := c.Gridders[gids[i]].Picks
+		if latest && len(picks) > len(gids) {
+			picks = picks[:len(gids)]
+		}
+		for _, pick := range picks {
+			if _, ok := response.findPick(pick); ok {
+				continue
 			}
-			response = append(response, &Keep{allowedPick, gid})
+			response = append(response, &Keep{pick, gids[i]})
+			assign(i + 1)
+			response = response[:len(response)-1]
 		}
-		newActions[mid] = response
-		u := utilityOne(c, newActions, mid)
-		result = append(result, &ActionUtility{response, u})
 	}
+	assign(0)
+}
 
-	return result
+// bestAssignment returns the assignment of the gridders to picks with
+// the highest utility for mid.  Ties go to the assignment found first,
+// which uses the latest picks in combo order.  It returns nil if no
+// assignment exists.  newActions[mid] is overwritten.
+func bestAssignment(c *Constants, mid ManagerID, gids []GridderID, newActions []Action) *ActionUtility {
+	var best *ActionUtility
+	c.assignments(gids, func(response Action) {
+		newActions[mid] = response
+		u := utilityOne(c, newActions, mid)
+		if best == nil || u > best.Utility {
+			best = &ActionUtility{append(Action(nil), response...), u}
+		}
+	})
+	return best
 }
 
+// bestResponse returns mid's best response under their own beliefs.
 func bestResponse(c *Constants, mid ManagerID, actions []Action) Action {
-	responses := AllResponses(c, mid, actions)
+	responses := AllResponses(c.believed(mid), mid, actions)
 	bestI := 0
 	for i := 1; i < len(responses); i++ {
 		if responses[i].Utility > responses[bestI].Utility {
@@ -204,19 +414,33 @@ func bestResponse(c *Constants, mid ManagerID, actions []Action) Action {
 }
 
 func utilityOne(c *Constants, actions []Action, mid1 ManagerID) float64 {
+	if c.Simulator != nil {
+		return c.Simulator.UtilityAll(actions)[mid1]
+	}
 	result := 0.0
-	utilityAccum(c, actions, func(pick int, mid ManagerID, gid GridderID, _ bool) {
+	utilityAccum(c, actions, func(pick int, mid ManagerID, gid GridderID, iskeep bool) {
 		if mid == mid1 {
-			result += c.Gridders[gid].Value
+			if iskeep {
+				result += c.keepValue(gid, pick)
+			} else {
+				result += c.Gridders[gid].Value
+			}
 		}
 	})
 	return result
 }
 
 func UtilityAll(c *Constants, actions []Action) []float64 {
+	if c.Simulator != nil {
+		return c.Simulator.UtilityAll(actions)
+	}
 	result := make([]float64, len(actions))
-	utilityAccum(c, actions, func(pick int, mid ManagerID, gid GridderID, _ bool) {
-		result[mid] += c.Gridders[gid].Value
+	utilityAccum(c, actions, func(pick int, mid ManagerID, gid GridderID, iskeep bool) {
+		if iskeep {
+			result[mid] += c.keepValue(gid, pick)
+		} else {
+			result[mid] += c.Gridders[gid].Value
+		}
 	})
 	return result
 }
@@ -270,7 +494,33 @@ func rosters(c *Constants, actions []Action) [][]GridderID {
 	return result
 }
 
-func ReadConstants(dataDir string, reveal bool) (*Constants, error) {
+func ReadConstants(dataDir string, league *fantasy.League, reveal bool) (*Constants, error) {
+	options, err := ReadOptions(path.Join(dataDir, "out", "keeper-options.csv"))
+	if err != nil {
+		return nil, err
+	}
+	c, err := BuildConstants(dataDir, league, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only for reveal mode.
+	if reveal {
+		c.Ideal, err = ReadValidActions(c, path.Join(dataDir, "out", "keeper-ideal.csv"))
+		if err != nil {
+			return nil, err
+		}
+		c.Actual, err = ReadValidActions(c, path.Join(dataDir, "managers", "keeper-selections.csv"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// BuildConstants reads the player values and draft order from dataDir
+// and combines them with the keeper options.
+func BuildConstants(dataDir string, league *fantasy.League, options []*Option) (*Constants, error) {
 	g, err := os.Open(path.Join(dataDir, "out", "player-values.csv"))
 	if err != nil {
 		return nil, err
@@ -298,43 +548,25 @@ func ReadConstants(dataDir string, reveal bool) (*Constants, error) {
 		})
 	}
 
-	k, err := os.Open(path.Join(dataDir, "out", "keeper-options.csv"))
-	if err != nil {
-		return nil, err
-	}
-	defer k.Close()
-
-	krecords, err := csv.NewReader(k).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("keeper options: %s", err)
-	}
-
 	var managers []*Manager
 	mids := make(map[string]ManagerID)
-	for _, record := range krecords[1:] { // skip header
-		managerName := record[0]
-		playerName := record[1]
-
-		round := 0
-		if record[3] != "n/a" {
-			round, err = strconv.Atoi(record[3])
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
-
-		mid, ok := mids[managerName]
+	for _, o := range options {
+		mid, ok := mids[o.Manager]
 		if !ok {
 			mid = ManagerID(len(managers))
-			mids[managerName] = mid
+			mids[o.Manager] = mid
 			managers = append(managers, &Manager{
-				Name: managerName,
+				Name: o.Manager,
 			})
 		}
-		gid := gids[playerName]
+		gid, ok := gids[o.Player]
+		if !ok {
+			return nil, fmt.Errorf("keeper options: no value for player %q", o.Player)
+		}
 		managers[mid].GIDs = append(managers[mid].GIDs, gid)
 		gridders[gid].MID = mid
-		gridders[gid].Round = round
+		gridders[gid].Round = o.Round
+		gridders[gid].Kept = o.Kept
 	}
 
 	o, err := os.Open(path.Join(dataDir, "yahoo", "draft-order.csv"))
@@ -353,107 +585,25 @@ func ReadConstants(dataDir string, reveal bool) (*Constants, error) {
 	for _, record := range orecords[1:] { // skip header
 		pick, err := strconv.Atoi(record[0])
 		if err != nil {
-			log.Fatal(err)
+			return nil, fmt.Errorf("draft order: %s", err)
 		}
 		managerName := record[1]
 		viaTrade := strings.TrimSpace(record[2]) != ""
 
 		if pick != len(picks)+1 {
-			log.Fatalf("Out of order pick: %d\n", pick)
+			return nil, fmt.Errorf("draft order: out of order pick %d", pick)
 		}
 
 		mid, ok := mids[managerName]
 		if !ok {
-			log.Fatalf("No manager with name %q", managerName)
+			return nil, fmt.Errorf("draft order: no manager with name %q", managerName)
 		}
 
 		picks = append(picks, mid)
 		picksViaTrade = append(picksViaTrade, viaTrade)
 	}
-
-	// Only for reveal mode.
-	ideal := make([]Action, len(mids))
-	actual := make([]Action, len(mids))
-	if reveal {
-		id, err := os.Open(path.Join(dataDir, "out", "keeper-ideal.csv"))
-		if err != nil {
-			return nil, err
-		}
-		defer id.Close()
-
-		idrecords, err := csv.NewReader(id).ReadAll()
-		if err != nil {
-			return nil, fmt.Errorf("keeper ideal: %s", err)
-		}
-
-		for _, record := range idrecords[1:] { // skip header
-			managerName := record[0]
-			gridderName := record[1]
-			roundPick := record[2]
-
-			mid, ok := mids[managerName]
-			if !ok {
-				log.Fatalf("No manager with name %q", managerName)
-			}
-
-			gid, ok := gids[gridderName]
-			if !ok {
-				log.Fatalf("No gridder with name %q", gridderName)
-			}
-
-			dash := strings.Index(roundPick, "-")
-			pick, err := strconv.Atoi(roundPick[dash+1:])
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			ideal[mid] = append(ideal[mid], &Keep{pick - 1, gid})
-		}
-
-		a, err := os.Open(path.Join(dataDir, "managers", "keeper-selections.csv"))
-		if err != nil {
-			return nil, err
-		}
-		defer a.Close()
-
-		arecords, err := csv.NewReader(a).ReadAll()
-		if err != nil {
-			return nil, fmt.Errorf("keeper selections: %s", err)
-		}
-
-		for _, record := range arecords[1:] { // skip header
-			managerName := record[0]
-			gridderName := record[1]
-			roundPick := record[2]
-
-			mid, ok := mids[managerName]
-			if !ok {
-				log.Fatalf("No manager with name %q", managerName)
-			}
-
-			gid, ok := gids[gridderName]
-			if !ok {
-				log.Fatalf("No gridder with name %q", gridderName)
-			}
-
-			dash := strings.Index(roundPick, "-")
-			pick, err := strconv.Atoi(roundPick[dash+1:])
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			// TODO: Check that this manager owns this pick.
-			round, err := strconv.Atoi(roundPick[:dash])
-			if err != nil {
-				log.Fatal(err)
-			}
-			if (pick-1)/12 != round-1 {
-				log.Fatal(roundPick)
-			}
-
-			actual[mid] = append(actual[mid], &Keep{pick - 1, gid})
-		}
+	if len(managers) != league.Teams {
+		return nil, fmt.Errorf("keeper options have %d managers, want %d teams", len(managers), league.Teams)
 	}
-
-	return newConstants(gridders, managers, picks, picksViaTrade, ideal, actual), nil
+	return newConstants(gridders, managers, picks, picksViaTrade, league.MaxKeepers, nil, nil), nil
 }
@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"testing"
+)
+
+// testConstants builds constants for a snake draft where gridder i has
+// value values[i], is owned by owners[i] (-1 if unowned) and is keepable
+// in rounds[i] or earlier.
+func testConstants(numManagers, numRounds, maxKeepers int, values []float64, owners []ManagerID, rounds []int) *Constants {
+	managers := make([]*Manager, numManagers)
+	for m := range managers {
+		managers[m] = &Manager{Name: string(rune('A' + m))}
+	}
+	var gridders []*Gridder
+	for i, v := range values {
+		gridders = append(gridders, &Gridder{
+			Name:  string(rune('a' + i)),
+			Value: v,
+			MID:   owners[i],
+			Round: rounds[i],
+		})
+		if owners[i] >= 0 {
+			managers[owners[i]].GIDs = append(managers[owners[i]].GIDs, GridderID(i))
+		}
+	}
+	var picks []ManagerID
+	var picksViaTrade []bool
+	for r := 0; r < numRounds; r++ {
+		for m := 0; m < numManagers; m++ {
+			if r%2 == 1 {
+				picks = append(picks, ManagerID(numManagers-1-m))
+			} else {
+				picks = append(picks, ManagerID(m))
+			}
+			picksViaTrade = append(picksViaTrade, false)
+		}
+	}
+	return newConstants(gridders, managers, picks, picksViaTrade, maxKeepers, nil, nil)
+}
+
+func TestIteratedBestResponseConverges(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	for _, sequential := range []bool{false, true} {
+		result := IteratedBestResponse(c, &IBROptions{Sequential: sequential})
+		if !result.Converged || result.CycleLength != 0 {
+			t.Errorf("sequential=%v: Converged = %v, CycleLength = %d; want converged", sequential, result.Converged, result.CycleLength)
+		}
+		if !result.Equilibrium {
+			t.Errorf("sequential=%v: Equilibrium = false; want true", sequential)
+		}
+		last := result.Profiles[len(result.Profiles)-1]
+		want := []Action{{{Pick: 4, GID: 0}}, {{Pick: 5, GID: 1}}}
+		if !equalProfiles(last, want) {
+			t.Errorf("sequential=%v: last profile = %v; want %v", sequential, last, want)
+		}
+	}
+}
+
+func TestIsEquilibrium(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	if IsEquilibrium(c, []Action{nil, nil}) {
+		t.Errorf("IsEquilibrium(nobody keeps) = true; want false")
+	}
+	if !IsEquilibrium(c, []Action{{{Pick: 4, GID: 0}}, {{Pick: 5, GID: 1}}}) {
+		t.Errorf("IsEquilibrium(both keep) = false; want true")
+	}
+}
+
+func TestActionEqual(t *testing.T) {
+	a := Action{{Pick: 1, GID: 2}, {Pick: 3, GID: 4}}
+	tests := []struct {
+		b    Action
+		want bool
+	}{
+		{Action{{Pick: 3, GID: 4}, {Pick: 1, GID: 2}}, true},
+		{Action{{Pick: 1, GID: 2}}, false},
+		{Action{{Pick: 1, GID: 2}, {Pick: 3, GID: 5}}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := a.Equal(tt.b); got != tt.want {
+			t.Errorf("Equal(%v) = %v; want %v", tt.b, got, tt.want)
+		}
+	}
+	if !Action(nil).Equal(Action{}) {
+		t.Errorf("nil.Equal(empty) = false; want true")
+	}
+}
+
+func TestIteratedBestResponsePinned(t *testing.T) {
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20, 10, 5},
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	// A announced keeping nobody, though keeping a is better for them.
+	pinned := map[ManagerID]Action{0: {}}
+	result := IteratedBestResponse(c, &IBROptions{Pinned: pinned})
+	if !result.Converged || !result.Equilibrium {
+		t.Errorf("Converged = %v, Equilibrium = %v; want both", result.Converged, result.Equilibrium)
+	}
+	last := result.Profiles[len(result.Profiles)-1]
+	want := []Action{{}, {{Pick: 5, GID: 1}}}
+	if !equalProfiles(last, want) {
+		t.Errorf("last profile = %v; want %v", last, want)
+	}
+	if IsEquilibrium(c, last) {
+		t.Errorf("IsEquilibrium ignoring pins = true; want false")
+	}
+}
+
+func TestAllResponsesAssignment(t *testing.T) {
+	// A's picks are 0, 3 and 4, in rounds 1, 2 and 3.
+	tests := []struct {
+		desc   string
+		rounds []int
+		future [][]float64
+		want   Action
+	}{
+		{"same round", []int{3, 3}, nil, Action{{Pick: 4, GID: 0}, {Pick: 3, GID: 1}}},
+		{"earlier round first", []int{2, 3}, nil, Action{{Pick: 3, GID: 0}, {Pick: 4, GID: 1}}},
+		{"later round first", []int{3, 2}, nil, Action{{Pick: 4, GID: 0}, {Pick: 3, GID: 1}}},
+		// Keeping b in round 3 is worth more than keeping a there, so
+		// the greedy assignment of a to the latest pick is wrong.
+		{"future value", []int{3, 3}, [][]float64{nil, {0, 0, 30}}, Action{{Pick: 3, GID: 0}, {Pick: 4, GID: 1}}},
+		{"overlap with one round", []int{1, 2}, nil, Action{{Pick: 0, GID: 0}, {Pick: 3, GID: 1}}},
+	}
+	for _, test := range tests {
+		c := testConstants(2, 3, 2,
+			[]float64{50, 40, 30, 20, 10, 5, 4, 3},
+			[]ManagerID{0, 0, -1, -1, -1, -1, -1, -1},
+			append(test.rounds, 0, 0, 0, 0, 0, 0))
+		for i, f := range test.future {
+			c.Gridders[i].Future = f
+		}
+		var got Action
+		for _, au := range AllResponses(c, 0, []Action{nil, nil}) {
+			if len(au.Act) == 2 {
+				got = au.Act
+			}
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%s: keeping both = %v; want %v", test.desc, got, test.want)
+		}
+	}
+}
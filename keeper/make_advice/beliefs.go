@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"math/rand"
+
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	beliefsCsv  = flag.String("beliefs_csv", "", "CSV of manager,player,value giving other managers' beliefs; they best respond using these while utilities use ours")
+	beliefNoise = flag.Float64("belief_noise", 0, "if set and -beliefs_csv is not, other managers' values are ours times a normal factor with this stddev; needs -manager")
+)
+
+// setupBeliefs gives other managers their own beliefs about values.
+// Noisy beliefs need -manager, so that we keep our own values.
+func setupBeliefs(consts *keeper.Constants, rng *rand.Rand) *keeper.Constants {
+	if *beliefsCsv != "" {
+		beliefs, err := keeper.ReadBeliefs(consts, *beliefsCsv)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return consts.WithBeliefs(beliefs)
+	}
+	if *manager == "" {
+		log.Fatal("-belief_noise needs -manager")
+	}
+	us := keeper.ManagerID(-1)
+	for m, mgr := range consts.Managers {
+		if mgr.Name == *manager {
+			us = keeper.ManagerID(m)
+		}
+	}
+	if us == -1 {
+		log.Fatalf("no manager with name %q", *manager)
+	}
+	return consts.WithBeliefs(keeper.NoisyBeliefs(consts, us, *beliefNoise, rng))
+}
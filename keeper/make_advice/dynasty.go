@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	horizon  = flag.Int("horizon", 1, "seasons to value keepers over, including this one; 1 means this season only; ignored with -simulate")
+	discount = flag.Float64("discount", 0.85, "value of next season relative to this one, for -horizon")
+	agesCsv  = flag.String("ages_csv", "", "CSV of player,age for -horizon; players not listed don't age")
+	agingCsv = flag.String("aging_csv", "", "CSV of pos,age,factor for -horizon; empty means a built-in curve")
+)
+
+// setupDynasty adds the value of future seasons to keeping each gridder.
+func setupDynasty(consts *keeper.Constants, league *fantasy.League) *keeper.Constants {
+	rules, err := keeper.CompileRules(league.EligibilityRules())
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := &keeper.DynastyOptions{
+		Horizon:  *horizon,
+		Discount: *discount,
+		Ages:     make([]int, len(consts.Gridders)),
+		Aging:    keeper.DefaultAging(),
+		Rules:    rules,
+	}
+	if *agesCsv != "" {
+		opts.Ages, err = keeper.ReadAges(consts, *agesCsv)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *agingCsv != "" {
+		opts.Aging, err = keeper.ReadAging(*agingCsv)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	return consts.WithDynasty(opts)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	equilibria  = flag.Bool("equilibria", false, "list all pure equilibria if there are at most -max_profiles profiles")
+	maxProfiles = flag.Int("max_profiles", 100000, "maximum number of action profiles to check for -equilibria")
+	topK        = flag.Int("top", 0, "show each manager's top k keeper sets in response to the final profile; 0 means none")
+	closeCall   = flag.Float64("close", 5, "utility gap between the top two keeper sets below which a decision is a close call")
+)
+
+func listEquilibria(consts *keeper.Constants, opts *keeper.IBROptions) {
+	eqs, err := keeper.EnumerateEquilibria(consts, opts.Pinned, *maxProfiles)
+	if err != nil {
+		log.Fatalf("Can't enumerate equilibria: %s", err)
+	}
+	fmt.Printf("%d pure equilibria\n", len(eqs))
+	for i, profile := range eqs {
+		fmt.Printf("\n=== Equilibrium %d ===\n", i+1)
+		utils := keeper.UtilityAll(consts, profile)
+		for m, mgr := range consts.Managers {
+			fmt.Printf("%-30s %8.1f  %s\n", mgr.Name, utils[m], consts.GridderNames(profile[m].SortedGIDs()))
+		}
+	}
+	fmt.Printf("\n")
+}
+
+// topResponses prints each manager's top keeper sets, with clear-cut
+// decisions first and close calls after.
+func topResponses(consts *keeper.Constants, profile []keeper.Action) {
+	var clearCut, closeCalls []keeper.ManagerID
+	tops := make([][]*keeper.ActionUtility, len(consts.Managers))
+	for m, mgr := range consts.Managers {
+		if *manager != "" && mgr.Name != *manager {
+			continue
+		}
+		mid := keeper.ManagerID(m)
+		tops[m] = keeper.TopResponses(consts, mid, profile, *topK)
+		if len(tops[m]) > 1 && tops[m][0].Utility-tops[m][1].Utility < *closeCall {
+			closeCalls = append(closeCalls, mid)
+		} else {
+			clearCut = append(clearCut, mid)
+		}
+	}
+	for _, section := range []struct {
+		title string
+		mids  []keeper.ManagerID
+	}{{"CLEAR-CUT", clearCut}, {"CLOSE CALLS", closeCalls}} {
+		fmt.Printf("================= %s ==============\n", section.title)
+		for _, mid := range section.mids {
+			fmt.Printf("\n%s\n", consts.Managers[mid].Name)
+			top := tops[mid]
+			for i, au := range top {
+				fmt.Printf("  %d. %8.1f %8.1f  %s\n", i+1, au.Utility, au.Utility-top[0].Utility, consts.GridderNames(au.Act.SortedGIDs()))
+			}
+		}
+		fmt.Printf("\n")
+	}
+}
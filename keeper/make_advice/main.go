@@ -4,36 +4,106 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"os"
-	"path"
+	"math/rand"
 	"strconv"
 	"strings"
 
+	"github.com/dbtleonia/fantasy"
 	"github.com/dbtleonia/fantasy/keeper"
 )
 
 var (
-	ideal   = flag.Bool("ideal", false, "make ideal CSV")
-	dataDir = flag.String("data_dir", "", "directory for data files; empty string means $HOME/data")
+	ideal      = flag.Bool("ideal", false, "make ideal CSV")
+	dataDir    = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg  = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+	sequential = flag.Bool("sequential", false, "update managers one at a time instead of simultaneously")
+	maxRounds  = flag.Int("max_rounds", 20, "maximum rounds of best responses")
+	manager    = flag.String("manager", "", "our manager; only this manager is reported by -samples and tracked by -pinned")
 )
 
 func main() {
 	flag.Parse()
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 	dir := *dataDir
 	if dir == "" {
-		if home := os.Getenv("HOME"); home != "" {
-			dir = path.Join(home, "data")
-		}
+		dir = league.Dir()
 	}
 
-	consts, err := keeper.ReadConstants(dir, false)
+	consts, err := keeper.ReadConstants(dir, league, false)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	profiles := keeper.IteratedBestResponse(consts)
+	if *simulate && *samples > 0 {
+		log.Fatal("-samples can't be used with -simulate; simulated drafts score projected points, not sampled values")
+	}
+	noisy := *beliefsCsv == "" && *beliefNoise > 0
+	if *samples > 0 && (*beliefsCsv != "" || noisy || *horizon > 1) {
+		log.Fatal("-samples can't be used with -beliefs_csv, -belief_noise or -horizon; only our values are sampled")
+	}
+	if *simulate && (*beliefsCsv != "" || noisy) {
+		log.Fatal("-beliefs_csv and -belief_noise can't be used with -simulate; simulated drafts don't use values")
+	}
+	var rng *rand.Rand
+	if *simulate || *samples > 0 || noisy {
+		rng = newRand()
+	}
+
+	// Beliefs come first so that each manager's future values are
+	// computed from their own values.
+	if *beliefsCsv != "" || noisy {
+		consts = setupBeliefs(consts, rng)
+	}
+	if *horizon > 1 {
+		consts = setupDynasty(consts, league)
+	}
+
+	opts := &keeper.IBROptions{
+		MaxRounds:  *maxRounds,
+		Sequential: *sequential,
+	}
+	// The simulator comes before pinning so the what-if table uses it
+	// too.
+	if *simulate {
+		setupSimulator(consts, league, rng)
+	}
+	if *pinnedCsv != "" {
+		pin(consts, opts)
+	}
+	if *samples > 0 {
+		monteCarlo(consts, opts, rng)
+		return
+	}
+
+	if *equilibria {
+		listEquilibria(consts, opts)
+	}
+
+	result := keeper.IteratedBestResponse(consts, opts)
+	profiles := result.Profiles
+	if *topK > 0 && !*ideal {
+		topResponses(consts, profiles[len(profiles)-1])
+	}
+	if !*ideal {
+		switch {
+		case result.Converged:
+			fmt.Printf("Converged after %d rounds", len(profiles))
+		case result.CycleLength > 0:
+			fmt.Printf("Cycle of length %d after %d rounds", result.CycleLength, len(profiles))
+		default:
+			fmt.Printf("No convergence after %d rounds", len(profiles))
+		}
+		if result.Equilibrium {
+			fmt.Printf("; final profile is an equilibrium\n")
+		} else {
+			fmt.Printf("; final profile is NOT an equilibrium\n")
+		}
+	}
 
 	// Output the results.  This currently loops through the entire
 	// response for each gridder.  We could make it more efficient if
@@ -64,7 +134,7 @@ func main() {
 			}
 			if *ideal {
 				if i == len(profiles)-1 && pick >= 0 {
-					fmt.Printf("%s,%s,%d-%d\n", managerName, gridder.Name, pick/12+1, pick+1)
+					fmt.Printf("%s,%s,%d-%d\n", managerName, gridder.Name, pick/len(consts.Managers)+1, pick+1)
 				}
 			} else {
 				if pick >= 0 {
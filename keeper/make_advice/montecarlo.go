@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	samples      = flag.Int("samples", 0, "number of sampled value sets for Monte Carlo analysis; 0 means none")
+	stddevCsv    = flag.String("stddev_csv", "", "CSV of player,stddev for sampling values")
+	stddevFrac   = flag.Float64("stddev_frac", 0.2, "value stddev as a fraction of value, for players not in -stddev_csv")
+	valueSources = flag.String("value_sources", "", "comma-separated player value CSVs to sample from instead of a normal model")
+	seed         = flag.Int64("seed", 0, "seed for rand; if 0 uses time")
+	numOptions   = flag.Int("options", 5, "number of options to report per manager")
+)
+
+func newRand() *rand.Rand {
+	s := *seed
+	if s == 0 {
+		s = time.Now().Unix()
+	}
+	log.Printf("Using seed %d\n", s)
+	return rand.New(rand.NewSource(s))
+}
+
+func monteCarlo(consts *keeper.Constants, opts *keeper.IBROptions, rng *rand.Rand) {
+	var model keeper.ValueModel
+	if *valueSources != "" {
+		var sources [][]float64
+		for _, filename := range strings.Split(*valueSources, ",") {
+			values, err := keeper.ReadValues(consts, filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+			sources = append(sources, values)
+		}
+		model = &keeper.SourceValues{Sources: sources}
+	} else {
+		stddevs, err := keeper.ReadStddevs(consts, *stddevCsv, *stddevFrac)
+		if err != nil {
+			log.Fatal(err)
+		}
+		model = &keeper.NormalValues{Mean: consts.Values(), Stddev: stddevs}
+	}
+
+	options := keeper.MonteCarlo(consts, model, *samples, rng, opts)
+	for m, mgr := range consts.Managers {
+		if *manager != "" && mgr.Name != *manager {
+			continue
+		}
+		fmt.Printf("\n+++++++++++++++ %s ++++++++++++++\n\n", mgr.Name)
+		fmt.Printf("%6s %8s %8s %8s  %s\n", "best", "mean", "p10", "worst", "keep")
+		for i, o := range options[m] {
+			if i == *numOptions {
+				break
+			}
+			fmt.Printf("%5.1f%% %8.1f %8.1f %8.1f  %s\n",
+				100*float64(o.Best)/float64(*samples),
+				o.Mean(),
+				o.Quantile(0.1),
+				o.Quantile(0),
+				consts.GridderNames(o.GIDs))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var pinnedCsv = flag.String("pinned", "", "partial keeper-selections CSV of announced keepers; those managers are pinned to their announced actions")
+
+// pin reads the announced keepers into opts.  If -manager is set and
+// output is not -ideal, it first shows how our best response changes as
+// each announcement is pinned in turn.
+func pin(consts *keeper.Constants, opts *keeper.IBROptions) {
+	actions, order, err := keeper.ReadActions(consts, *pinnedCsv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := keeper.Validate(consts, actions); err != nil {
+		log.Fatalf("%s:\n%s", *pinnedCsv, err)
+	}
+	if *manager != "" && !*ideal {
+		whatIf(consts, opts, actions, order)
+	}
+	opts.Pinned = keeper.Pins(actions, order)
+}
+
+// whatIf prints our best response with each prefix of the announcements
+// pinned.
+func whatIf(consts *keeper.Constants, opts *keeper.IBROptions, actions []keeper.Action, order []keeper.ManagerID) {
+	mid := keeper.ManagerID(-1)
+	for m, mgr := range consts.Managers {
+		if mgr.Name == *manager {
+			mid = keeper.ManagerID(m)
+		}
+	}
+	if mid == -1 {
+		log.Fatalf("No manager with name %q", *manager)
+	}
+
+	fmt.Printf("%-30s %8s  %s\n", "pinned", "utility", "best for "+*manager)
+	for k := 0; k <= len(order); k++ {
+		label := "(none)"
+		if k > 0 {
+			if order[k-1] == mid {
+				continue // our own announcement
+			}
+			label = "+ " + consts.Managers[order[k-1]].Name
+		}
+		o := *opts
+		o.Pinned = keeper.Pins(actions, order[:k])
+		delete(o.Pinned, mid)
+		result := keeper.IteratedBestResponse(consts, &o)
+		last := result.Profiles[len(result.Profiles)-1]
+		fmt.Printf("%-30s %8.1f  %s\n", label, keeper.UtilityAll(consts, last)[mid], consts.GridderNames(last[mid].SortedGIDs()))
+	}
+	fmt.Printf("\n")
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"math/rand"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	simulate   = flag.Bool("simulate", false, "score keeper profiles by simulating drafts instead of summing values")
+	playersCsv = flag.String("players_csv", "", "players CSV from genplayers, without keeper picks, for -simulate")
+	rulesCsv   = flag.String("rules_csv", "", "rules CSV from genrules, for -simulate")
+	trials     = flag.Int("trials", 20, "number of simulated drafts per keeper profile, for -simulate")
+	bench      = flag.Bool("bench", false, "score bench (using hardcoded weights), for -simulate")
+)
+
+func setupSimulator(consts *keeper.Constants, league *fantasy.League, rng *rand.Rand) {
+	if *playersCsv == "" || *rulesCsv == "" {
+		log.Fatal("-simulate requires -players_csv and -rules_csv")
+	}
+	players, err := fantasy.ReadPlayers(*playersCsv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rules, err := fantasy.ReadRules(*rulesCsv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	consts.Simulator, err = keeper.NewSimulator(consts, players, rules, league.Scorer(*bench), *trials, rng)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	dataDir   = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s <year>\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	year, err := strconv.Atoi(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dir := *dataDir
+	if dir == "" {
+		dir = league.Dir()
+	}
+	rules, err := keeper.CompileRules(league.EligibilityRules())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	h, err := keeper.ReadYahooHistory(path.Join(dir, "yahoo"), year, keeper.Seasons(rules))
+	if err != nil {
+		log.Fatal(err)
+	}
+	options, err := keeper.ComputeOptions(h, rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outDir := path.Join(dir, "out")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	filename := path.Join(outDir, "keeper-options.csv")
+	fmt.Printf("Writing %s\n", filename)
+	if err := keeper.WriteOptions(filename, year, options); err != nil {
+		log.Fatal(err)
+	}
+}
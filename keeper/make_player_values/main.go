@@ -11,6 +11,8 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/dbtleonia/fantasy"
 )
 
 var (
@@ -34,44 +36,8 @@ var (
 	//   field 0  = <player-canon>
 	//   field 1  = <value>
 	//
-	dataDir = flag.String("data_dir", "", "directory for data files; empty string means $HOME/data")
-
-	// Mapping of team formats from raw -> canon.
-	teams = map[string]string{
-		"ARI": "Ari",
-		"ATL": "Atl",
-		"BAL": "Bal",
-		"BUF": "Buf",
-		"CAR": "Car",
-		"CHI": "Chi",
-		"CIN": "Cin",
-		"CLE": "Cle",
-		"DAL": "Dal",
-		"DEN": "Den",
-		"DET": "Det",
-		"GB":  "GB",
-		"HOU": "Hou",
-		"IND": "Ind",
-		"JAC": "Jax",
-		"KC":  "KC",
-		"LAC": "LAC",
-		"LAR": "LAR",
-		"LV":  "LV",
-		"MIA": "Mia",
-		"MIN": "Min",
-		"NE":  "NE",
-		"NO":  "NO",
-		"NYG": "NYG",
-		"NYJ": "NYJ",
-		"PHI": "Phi",
-		"PIT": "Pit",
-		"SEA": "Sea",
-		"SF":  "SF",
-		"TB":  "TB",
-		"TEN": "Ten",
-		"WAS": "Was",
-		"":    "",
-	}
+	dataDir   = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
 
 	teamRE = regexp.MustCompile(`\([^ ]*`)
 )
@@ -90,9 +56,9 @@ func translateName(playerRaw string) (string, error) {
 	var err error
 	result := teamRE.ReplaceAllStringFunc(playerRaw, func(teamRaw string) string {
 		teamRaw = teamRaw[1:] // strip '('
-		teamCanon, ok := teams[teamRaw]
-		if !ok {
-			err = fmt.Errorf("unknown team: %q in player %q", teamRaw, playerRaw)
+		teamCanon, e := fantasy.CanonTeam(teamRaw)
+		if e != nil {
+			err = fmt.Errorf("%s in player %q", e, playerRaw)
 			return ""
 		}
 		return "(" + teamCanon
@@ -122,11 +88,13 @@ func mustReadAll(filename string) [][]string {
 func main() {
 	flag.Parse()
 
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 	dir := *dataDir
 	if dir == "" {
-		if home := os.Getenv("HOME"); home != "" {
-			dir = path.Join(home, "data")
-		}
+		dir = league.Dir()
 	}
 
 	// Read extra renames.
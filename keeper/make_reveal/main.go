@@ -2,28 +2,21 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"log"
 	"math"
 	"os"
-	"path"
 	"sort"
-	"strings"
 
+	"github.com/dbtleonia/fantasy"
 	"github.com/dbtleonia/fantasy/keeper"
 )
 
 var (
-	dataDir = flag.String("data_dir", "", "directory for data files; empty string means $HOME/data")
+	dataDir   = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+	format    = flag.String("format", "text", "output format: text, json or html")
 )
 
-func boolString(b bool, s string) string {
-	if b {
-		return s
-	}
-	return strings.Repeat(" ", len(s))
-}
-
 func numBetterThan(utils []float64, mid keeper.ManagerID, util float64) int {
 	n := 0
 	for otherMid, otherUtil := range utils {
@@ -42,30 +35,20 @@ func makeRanks(n int) []int {
 	return ranks
 }
 
-func ord(n int) string {
-	switch n {
-	case 1:
-		return "1st"
-	case 2:
-		return "2nd"
-	case 3:
-		return "3rd"
-	}
-	return fmt.Sprintf("%dth", n)
-}
-
 func main() {
 	flag.Parse()
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 	dir := *dataDir
 	if dir == "" {
-		if home := os.Getenv("HOME"); home != "" {
-			dir = path.Join(home, "data")
-		}
+		dir = league.Dir()
 	}
 
-	consts, err := keeper.ReadConstants(dir, true)
+	consts, err := keeper.ReadConstants(dir, league, true)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -113,47 +96,39 @@ func main() {
 		utilsBest[mid] = bestAU.Utility
 	}
 
+	r := &report{}
 	for m, manager := range consts.Managers {
 		mid := keeper.ManagerID(m)
-		rankStart := 1 + numBetterThan(utilsStart, mid, utilsStart[mid])
-		rankIdeal := 1 + numBetterThan(utilsIdeal, mid, utilsIdeal[mid])
-		rankActual := 1 + numBetterThan(utilsActual, mid, utilsActual[mid])
-		// Yes, utilsActual.  This is not truly a rank.
-		rankBest := 1 + numBetterThan(utilsActual, mid, utilsBest[mid])
-
-		fmt.Printf("+++++++++++++++ %s ++++++++++++++\n\n", manager.Name)
+		mr := managerReport{
+			Name:       manager.Name,
+			Score:      scores[mid],
+			StartRank:  1 + numBetterThan(utilsStart, mid, utilsStart[mid]),
+			IdealRank:  1 + numBetterThan(utilsIdeal, mid, utilsIdeal[mid]),
+			ActualRank: 1 + numBetterThan(utilsActual, mid, utilsActual[mid]),
+			// Yes, utilsActual.  This is not truly a rank.
+			BestRank: 1 + numBetterThan(utilsActual, mid, utilsBest[mid]),
+		}
 		for _, g := range manager.GIDs {
 			gid := keeper.GridderID(g)
-			if consts.Gridders[gid].Round == 0 {
-				// Gridder was round 1 keeper last year; not eligible this year.
-				fmt.Printf("%32s n/a\n",
-					consts.Gridders[gid].Name)
-			} else if len(consts.Gridders[gid].Picks) == 0 {
-				// Gridder can't be kept because manager has no pick that round or earlier.
-				fmt.Printf("%32s R%2d  n/a\n",
-					consts.Gridders[gid].Name,
-					consts.Gridders[gid].Round)
-			} else {
-				fmt.Printf("%32s R%2d %4d %s %s\n",
-					consts.Gridders[gid].Name,
-					consts.Gridders[gid].Round,
-					int(gridderDiffs[gid]),
-					boolString(gridderBest[gid], "BEST"),
-					boolString(consts.Actual[mid].HasGID(gid), "ACTUAL"))
+			gridder := consts.Gridders[gid]
+			gr := gridderReport{
+				Name:  gridder.Name,
+				Round: gridder.Round,
+				// Round 0 means a round 1 keeper last year, not eligible
+				// this year.  No picks means the manager has no pick that
+				// round or earlier.
+				Keepable: gridder.Round != 0 && len(gridder.Picks) > 0,
+			}
+			if gr.Keepable {
+				gr.Delta = int(gridderDiffs[gid])
+				gr.Best = gridderBest[gid]
+				gr.Actual = consts.Actual[mid].HasGID(gid)
 			}
+			mr.Gridders = append(mr.Gridders, gr)
 		}
-		fmt.Printf("\n")
-		fmt.Printf("SCORE = %d\n", scores[mid])
-		fmt.Printf("\n")
-		fmt.Printf("Start rank  = %s\n", ord(rankStart))
-		fmt.Printf("Ideal rank  = %s\n", ord(rankIdeal))
-		fmt.Printf("Actual rank = %s\n", ord(rankActual))
-		fmt.Printf("Best rank   = %s\n", ord(rankBest))
-		fmt.Printf("\n")
+		r.Managers = append(r.Managers, mr)
 	}
 
-	fmt.Printf("================= REPORT CARD ==============\n\n")
-
 	ranksStart := makeRanks(len(consts.Managers))  // []int{0, .., 11}
 	ranksIdeal := makeRanks(len(consts.Managers))  // []int{0, .., 11}
 	ranksActual := makeRanks(len(consts.Managers)) // []int{0, .., 11}
@@ -166,21 +141,26 @@ func main() {
 	sort.Slice(ranksActual, func(i, j int) bool {
 		return utilsActual[ranksActual[i]] > utilsActual[ranksActual[j]]
 	})
-
-	fmt.Printf("%4s   %-15s   %-15s   %-15s\n", "rank", "start", "ideal", "actual")
-	fmt.Printf("%4s   %-15s   %-15s   %-15s\n", "====", "=====", "=====", "======")
 	for i := 0; i < len(consts.Managers); i++ {
-		fmt.Printf("%4s   %-15s   %-15s   %-15s\n",
-			ord(i+1),
-			truncate(consts.Managers[ranksStart[i]].Name, 15),
-			truncate(consts.Managers[ranksIdeal[i]].Name, 15),
-			truncate(consts.Managers[ranksActual[i]].Name, 15))
+		r.ReportCard = append(r.ReportCard, cardRow{
+			Rank:   i + 1,
+			Start:  consts.Managers[ranksStart[i]].Name,
+			Ideal:  consts.Managers[ranksIdeal[i]].Name,
+			Actual: consts.Managers[ranksActual[i]].Name,
+		})
 	}
-}
 
-func truncate(s string, n int) string {
-	if len(s) > n {
-		return s[:n]
+	switch *format {
+	case "text":
+		writeText(os.Stdout, r)
+	case "json":
+		err = writeJSON(os.Stdout, r)
+	case "html":
+		err = writeHTML(os.Stdout, r)
+	default:
+		log.Fatalf("Unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
 	}
-	return s
 }
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+type report struct {
+	Managers   []managerReport `json:"managers"`
+	ReportCard []cardRow       `json:"report_card"`
+}
+
+type managerReport struct {
+	Name       string          `json:"name"`
+	Gridders   []gridderReport `json:"gridders"`
+	Score      int             `json:"score"` // percent of the best possible gain
+	StartRank  int             `json:"start_rank"`
+	IdealRank  int             `json:"ideal_rank"`
+	ActualRank int             `json:"actual_rank"`
+	BestRank   int             `json:"best_rank"`
+}
+
+type gridderReport struct {
+	Name     string `json:"name"`
+	Round    int    `json:"round"` // 0 if not eligible
+	Keepable bool   `json:"keepable"`
+
+	// Only meaningful if Keepable.
+	Delta  int  `json:"delta"` // utility of keeping just this gridder
+	Best   bool `json:"best"`
+	Actual bool `json:"actual"`
+}
+
+type cardRow struct {
+	Rank   int    `json:"rank"`
+	Start  string `json:"start"`
+	Ideal  string `json:"ideal"`
+	Actual string `json:"actual"`
+}
+
+func boolString(b bool, s string) string {
+	if b {
+		return s
+	}
+	return strings.Repeat(" ", len(s))
+}
+
+func ord(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func writeText(w io.Writer, r *report) {
+	for _, m := range r.Managers {
+		fmt.Fprintf(w, "+++++++++++++++ %s ++++++++++++++\n\n", m.Name)
+		for _, g := range m.Gridders {
+			switch {
+			case g.Round == 0:
+				fmt.Fprintf(w, "%32s n/a\n", g.Name)
+			case !g.Keepable:
+				fmt.Fprintf(w, "%32s R%2d  n/a\n", g.Name, g.Round)
+			default:
+				fmt.Fprintf(w, "%32s R%2d %4d %s %s\n",
+					g.Name,
+					g.Round,
+					g.Delta,
+					boolString(g.Best, "BEST"),
+					boolString(g.Actual, "ACTUAL"))
+			}
+		}
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "SCORE = %d\n", m.Score)
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Start rank  = %s\n", ord(m.StartRank))
+		fmt.Fprintf(w, "Ideal rank  = %s\n", ord(m.IdealRank))
+		fmt.Fprintf(w, "Actual rank = %s\n", ord(m.ActualRank))
+		fmt.Fprintf(w, "Best rank   = %s\n", ord(m.BestRank))
+		fmt.Fprintf(w, "\n")
+	}
+
+	fmt.Fprintf(w, "================= REPORT CARD ==============\n\n")
+	fmt.Fprintf(w, "%4s   %-15s   %-15s   %-15s\n", "rank", "start", "ideal", "actual")
+	fmt.Fprintf(w, "%4s   %-15s   %-15s   %-15s\n", "====", "=====", "=====", "======")
+	for _, row := range r.ReportCard {
+		fmt.Fprintf(w, "%4s   %-15s   %-15s   %-15s\n",
+			ord(row.Rank),
+			truncate(row.Start, 15),
+			truncate(row.Ideal, 15),
+			truncate(row.Actual, 15))
+	}
+}
+
+func writeJSON(w io.Writer, r *report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+var htmlTemplate = template.Must(template.New("reveal").Funcs(template.FuncMap{"ord": ord}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Keeper report card</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; }
+th { background: #eee; cursor: pointer; }
+td.num { text-align: right; }
+tr.actual { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Report card</h1>
+<table class="sortable">
+<tr><th>Manager</th><th>Score</th><th>Start rank</th><th>Ideal rank</th><th>Actual rank</th><th>Best rank</th></tr>
+{{range .Managers}}<tr><td>{{.Name}}</td><td class="num">{{.Score}}</td><td class="num" data-sort="{{.StartRank}}">{{ord .StartRank}}</td><td class="num" data-sort="{{.IdealRank}}">{{ord .IdealRank}}</td><td class="num" data-sort="{{.ActualRank}}">{{ord .ActualRank}}</td><td class="num" data-sort="{{.BestRank}}">{{ord .BestRank}}</td></tr>
+{{end}}</table>
+<table class="sortable">
+<tr><th>Rank</th><th>Start</th><th>Ideal</th><th>Actual</th></tr>
+{{range .ReportCard}}<tr><td class="num" data-sort="{{.Rank}}">{{ord .Rank}}</td><td>{{.Start}}</td><td>{{.Ideal}}</td><td>{{.Actual}}</td></tr>
+{{end}}</table>
+{{range .Managers}}<h2>{{.Name}}</h2>
+<table class="sortable">
+<tr><th>Player</th><th>Round</th><th>Delta</th><th>Best</th><th>Actual</th></tr>
+{{range .Gridders}}<tr{{if .Actual}} class="actual"{{end}}><td>{{.Name}}</td>{{if .Keepable}}<td class="num">{{.Round}}</td><td class="num">{{.Delta}}</td><td>{{if .Best}}BEST{{end}}</td><td>{{if .Actual}}ACTUAL{{end}}</td>{{else}}<td class="num">{{if .Round}}{{.Round}}{{else}}n/a{{end}}</td><td class="num" data-sort="-1e9">n/a</td><td></td><td></td>{{end}}</tr>
+{{end}}</table>
+{{end}}<script>
+// Click a header to sort by that column; click again to reverse.
+document.querySelectorAll("table.sortable").forEach(function(table) {
+  var headers = table.rows[0].cells;
+  for (var i = 0; i < headers.length; i++) {
+    headers[i].addEventListener("click", sorter(table, i));
+  }
+});
+function key(cell) {
+  var s = cell.dataset.sort !== undefined ? cell.dataset.sort : cell.textContent;
+  var n = parseFloat(s);
+  return isNaN(n) ? s.toLowerCase() : n;
+}
+function sorter(table, col) {
+  var asc = true;
+  return function() {
+    var rows = Array.prototype.slice.call(table.rows, 1);
+    rows.sort(function(a, b) {
+      var x = key(a.cells[col]), y = key(b.cells[col]);
+      var c = x < y ? -1 : x > y ? 1 : 0;
+      return asc ? c : -c;
+    });
+    rows.forEach(function(row) { table.tBodies[0].appendChild(row); });
+    asc = !asc;
+  };
+}
+</script>
+</body>
+</html>
+`))
+
+func writeHTML(w io.Writer, r *report) error {
+	return htmlTemplate.Execute(w, r)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	dataDir    = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg  = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+	historyDir = flag.String("history_dir", "", "directory with a subdirectory per past season laid out like the data dir; empty means <data_dir>/history")
+	ruleFiles  = flag.String("rules", "", "comma-separated JSON files of league settings to override, eg max_keepers and keeper_rules; the league's own rules are always included")
+	top        = flag.Int("top", 3, "number of managers with the most valuable rosters counted as top teams")
+	sequential = flag.Bool("sequential", false, "update managers one at a time instead of simultaneously")
+	maxRounds  = flag.Int("max_rounds", 20, "maximum rounds of best responses")
+)
+
+type ruleSet struct {
+	name   string
+	league *fantasy.League
+	rules  []*keeper.Rule
+}
+
+// loadRuleSet returns a copy of the base league with the settings in
+// filename overridden.  The copy is deep, so overriding keeper_rules or
+// flex doesn't change the base, and is validated like LoadLeague's.
+func loadRuleSet(base *fantasy.League, filename string) (*ruleSet, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	orig, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var l fantasy.League
+	if err := json.Unmarshal(orig, &l); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return newRuleSet(strings.TrimSuffix(path.Base(filename), ".json"), &l)
+}
+
+func newRuleSet(name string, l *fantasy.League) (*ruleSet, error) {
+	rules, err := keeper.CompileRules(l.EligibilityRules())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return &ruleSet{name, l, rules}, nil
+}
+
+type stats struct {
+	gini, advantage, spread float64
+	converged               bool
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	if flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s <year>...\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dir := *dataDir
+	if dir == "" {
+		dir = league.Dir()
+	}
+	hdir := *historyDir
+	if hdir == "" {
+		hdir = path.Join(dir, "history")
+	}
+
+	current, err := newRuleSet("current", league)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ruleSets := []*ruleSet{current}
+	if *ruleFiles != "" {
+		for _, filename := range strings.Split(*ruleFiles, ",") {
+			rs, err := loadRuleSet(league, filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ruleSets = append(ruleSets, rs)
+		}
+	}
+	seasons := 0
+	for _, rs := range ruleSets {
+		if n := keeper.Seasons(rs.rules); n > seasons {
+			seasons = n
+		}
+	}
+
+	opts := &keeper.IBROptions{
+		MaxRounds:  *maxRounds,
+		Sequential: *sequential,
+	}
+	totals := make([]stats, len(ruleSets))
+	fmt.Printf("%-6s %-20s %8s %10s %10s\n", "season", "rules", "gini", "top adv", "spread")
+	for _, arg := range flag.Args() {
+		year, err := strconv.Atoi(arg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		seasonDir := path.Join(hdir, arg)
+		h, err := keeper.ReadYahooHistory(path.Join(seasonDir, "yahoo"), year, seasons)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i, rs := range ruleSets {
+			options, err := keeper.ComputeOptions(h, rs.rules)
+			if err != nil {
+				log.Fatalf("%d %s: %s", year, rs.name, err)
+			}
+			consts, err := keeper.BuildConstants(seasonDir, rs.league, options)
+			if err != nil {
+				log.Fatalf("%d %s: %s", year, rs.name, err)
+			}
+			result := keeper.IteratedBestResponse(consts, opts)
+			utils := keeper.UtilityAll(consts, result.Profiles[len(result.Profiles)-1])
+			lo, hi := utils[0], utils[0]
+			for _, u := range utils {
+				if u < lo {
+					lo = u
+				}
+				if u > hi {
+					hi = u
+				}
+			}
+			s := stats{
+				gini:      keeper.Gini(utils),
+				advantage: keeper.TopAdvantage(consts, utils, *top),
+				spread:    hi - lo,
+				converged: result.Converged,
+			}
+			note := ""
+			if !s.converged {
+				note = "  (not converged)"
+			}
+			fmt.Printf("%-6d %-20s %8.4f %10.1f %10.1f%s\n", year, rs.name, s.gini, s.advantage, s.spread, note)
+			totals[i].gini += s.gini
+			totals[i].advantage += s.advantage
+			totals[i].spread += s.spread
+		}
+	}
+
+	n := float64(flag.NArg())
+	fmt.Printf("\n%-6s %-20s %8s %10s %10s\n", "", "rules", "gini", "top adv", "spread")
+	for i, rs := range ruleSets {
+		fmt.Printf("%-6s %-20s %8.4f %10.1f %10.1f\n", "mean", rs.name, totals[i].gini/n, totals[i].advantage/n, totals[i].spread/n)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestLoadRuleSet(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"one.json":  `{"keeper_rules": [{"when": "", "round": "n/a", "reason": "none"}]}`,
+		"more.json": `{"max_keepers": 5, "flex": {"Y": "RW"}}`,
+		"none.json": `{"max_keepers": 0}`,
+		"flex.json": `{"flex": {"": "R"}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(path.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defaults := append([]fantasy.KeeperRule(nil), fantasy.DefaultKeeperRules...)
+	base := fantasy.DefaultLeague()
+
+	one, err := loadRuleSet(base, path.Join(dir, "one.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	more, err := loadRuleSet(base, path.Join(dir, "more.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"none.json", "flex.json"} {
+		if _, err := loadRuleSet(base, path.Join(dir, name)); err == nil {
+			t.Errorf("%s: loadRuleSet succeeded, want error", name)
+		}
+	}
+
+	if len(one.league.KeeperRules) != 1 || one.league.MaxKeepers != 3 {
+		t.Errorf("one: got %d rules, %d keepers", len(one.league.KeeperRules), one.league.MaxKeepers)
+	}
+	if !reflect.DeepEqual(more.league.KeeperRules, defaults) || more.league.MaxKeepers != 5 {
+		t.Errorf("more: got rules %v, %d keepers", more.league.KeeperRules, more.league.MaxKeepers)
+	}
+	if want := map[string]string{"X": "R", "Y": "RW"}; !reflect.DeepEqual(more.league.Flex, want) {
+		t.Errorf("more: got flex %v, want %v", more.league.Flex, want)
+	}
+	if !reflect.DeepEqual(fantasy.DefaultKeeperRules, defaults) || !reflect.DeepEqual(base.KeeperRules, defaults) {
+		t.Error("overrides changed the default keeper rules")
+	}
+	if want := map[string]string{"X": "R"}; !reflect.DeepEqual(base.Flex, want) {
+		t.Errorf("overrides changed the base flex to %v", base.Flex)
+	}
+}
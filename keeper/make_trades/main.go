@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/keeper"
+)
+
+var (
+	dataDir    = flag.String("data_dir", "", "directory for data files; empty string means the league's data_dir")
+	leagueCfg  = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+	sequential = flag.Bool("sequential", false, "update managers one at a time instead of simultaneously")
+	maxRounds  = flag.Int("max_rounds", 20, "maximum rounds of best responses")
+	trade      = flag.String("trade", "", "picks to swap, as comma separated pairs of overall pick numbers, eg 5:20,30:43")
+	manager    = flag.String("manager", "", "rank one-for-one pick swaps for this manager")
+	maxGap     = flag.Int("max_gap", 2, "with -manager, only swap picks at most this many rounds apart")
+	top        = flag.Int("top", 10, "with -manager, number of swaps to show")
+)
+
+func parseSwaps(s string) ([]keeper.Swap, error) {
+	var swaps []keeper.Swap
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("bad swap %q", pair)
+		}
+		a, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, keeper.Swap{A: a - 1, B: b - 1})
+	}
+	return swaps, nil
+}
+
+func pickString(consts *keeper.Constants, pick int) string {
+	return fmt.Sprintf("%d-%d", pick/len(consts.Managers)+1, pick+1)
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	if (*trade == "") == (*manager == "") {
+		fmt.Fprintf(os.Stderr, "Usage: %s -trade <swaps> | -manager <name>\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dir := *dataDir
+	if dir == "" {
+		dir = league.Dir()
+	}
+
+	consts, err := keeper.ReadConstants(dir, league, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := &keeper.IBROptions{
+		MaxRounds:  *maxRounds,
+		Sequential: *sequential,
+	}
+
+	if *trade != "" {
+		swaps, err := parseSwaps(*trade)
+		if err != nil {
+			log.Fatal(err)
+		}
+		before := keeper.IteratedBestResponse(consts, opts)
+		t, err := keeper.EvaluateTrade(consts, before, swaps, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		traded, err := consts.WithTrade(swaps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range swaps {
+			fmt.Printf("%s gets %s, %s gets %s\n",
+				consts.Managers[traded.PickOwner(s.A)].Name, pickString(consts, s.A),
+				consts.Managers[traded.PickOwner(s.B)].Name, pickString(consts, s.B))
+		}
+		if !before.Converged || !t.Result.Converged {
+			fmt.Printf("WARNING: keeper equilibrium did not converge\n")
+		}
+		fmt.Printf("\n%-30s %8s %8s %8s\n", "manager", "before", "after", "change")
+		for m, mgr := range consts.Managers {
+			mid := keeper.ManagerID(m)
+			fmt.Printf("%-30s %8.1f %8.1f %+8.1f\n", mgr.Name, t.Before[mid], t.After[mid], t.Change(mid))
+		}
+		return
+	}
+
+	mid := keeper.ManagerID(-1)
+	for m, mgr := range consts.Managers {
+		if mgr.Name == *manager {
+			mid = keeper.ManagerID(m)
+		}
+	}
+	if mid == -1 {
+		log.Fatalf("No manager with name %q", *manager)
+	}
+	trades, err := keeper.RankTrades(consts, mid, *maxGap, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%-8s %-8s %-30s %8s %8s\n", "give", "get", "partner", "ours", "theirs")
+	for i, t := range trades {
+		if i == *top {
+			break
+		}
+		s := t.Swaps[0]
+		partner := consts.PickOwner(s.B)
+		fmt.Printf("%-8s %-8s %-30s %+8.1f %+8.1f\n",
+			pickString(consts, s.A), pickString(consts, s.B), consts.Managers[partner].Name,
+			t.Change(mid), t.Change(partner))
+	}
+}
@@ -0,0 +1,213 @@
+package keeper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A ValueModel samples a value for every gridder, indexed by GridderID.
+type ValueModel interface {
+	Sample(rng *rand.Rand) []float64
+}
+
+// NormalValues samples each value independently from a normal
+// distribution, truncated at zero.
+type NormalValues struct {
+	Mean   []float64
+	Stddev []float64
+}
+
+func (n *NormalValues) Sample(rng *rand.Rand) []float64 {
+	values := make([]float64, len(n.Mean))
+	for i, mean := range n.Mean {
+		values[i] = math.Max(0, mean+rng.NormFloat64()*n.Stddev[i])
+	}
+	return values
+}
+
+// SourceValues samples one of several sets of values, eg from different
+// ranking sites.
+type SourceValues struct {
+	Sources [][]float64
+}
+
+func (s *SourceValues) Sample(rng *rand.Rand) []float64 {
+	return s.Sources[rng.Intn(len(s.Sources))]
+}
+
+// WithValues returns a copy of the constants with new gridder values.
+func (c *Constants) WithValues(values []float64) *Constants {
+	gridders := make([]*Gridder, len(c.Gridders))
+	for i, g := range c.Gridders {
+		gridder := *g
+		gridder.Value = values[i]
+		gridders[i] = &gridder
+	}
+	gidsByValue := append([]GridderID(nil), c.gidsByValue...)
+	sort.Stable(byGridderValue{gidsByValue, gridders})
+
+	result := *c
+	result.Gridders = gridders
+	result.gidsByValue = gidsByValue
+	return &result
+}
+
+// Values returns the current value of every gridder.
+func (c *Constants) Values() []float64 {
+	values := make([]float64, len(c.Gridders))
+	for i, g := range c.Gridders {
+		values[i] = g.Value
+	}
+	return values
+}
+
+// ReadValues reads gridder values from a CSV file with a header and
+// columns player, value.  Gridders not in the file keep their current
+// value.
+func ReadValues(c *Constants, filename string) ([]float64, error) {
+	return readPerGridder(c, filename, c.Values())
+}
+
+// ReadStddevs reads gridder value stddevs from a CSV file with a header
+// and columns player, stddev.  Gridders not in the file get frac times
+// their value.  If filename is empty, every gridder gets frac times
+// their value.
+func ReadStddevs(c *Constants, filename string, frac float64) ([]float64, error) {
+	stddevs := make([]float64, len(c.Gridders))
+	for i, g := range c.Gridders {
+		stddevs[i] = frac * math.Abs(g.Value)
+	}
+	if filename == "" {
+		return stddevs, nil
+	}
+	return readPerGridder(c, filename, stddevs)
+}
+
+func readPerGridder(c *Constants, filename string, defaults []float64) ([]float64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	gids := make(map[string]GridderID)
+	for i, g := range c.Gridders {
+		gids[g.Name] = GridderID(i)
+	}
+	result := append([]float64(nil), defaults...)
+	for _, record := range records[1:] { // skip header
+		gid, ok := gids[record[0]]
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err)
+		}
+		result[gid] = v
+	}
+	return result, nil
+}
+
+// OptionStats summarizes one keeper option for a manager across
+// samples.
+type OptionStats struct {
+	GIDs      []GridderID // gridders kept, in ascending order
+	Best      int         // number of samples where this was the best response
+	Utilities []float64   // one per sample
+}
+
+func (o *OptionStats) Mean() float64 {
+	total := 0.0
+	for _, u := range o.Utilities {
+		total += u
+	}
+	return total / float64(len(o.Utilities))
+}
+
+// Quantile returns the q-quantile of the utilities, eg 0.1 for a
+// downside estimate.
+func (o *OptionStats) Quantile(q float64) float64 {
+	sorted := append([]float64(nil), o.Utilities...)
+	sort.Float64s(sorted)
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+// MonteCarlo solves the keeper game once per sampled set of values.  In
+// each sample, every manager's options are evaluated against the other
+// managers' actions in the last profile of iterated best response.  The
+// result has each manager's options sorted by mean utility descending.
+// Only the values are sampled; beliefs and Future values are not, so
+// they would stay fixed across samples, and a Simulator doesn't use
+// values at all.  So c shouldn't have any of them.
+func MonteCarlo(c *Constants, model ValueModel, samples int, rng *rand.Rand, opts *IBROptions) [][]*OptionStats {
+	options := make([]map[string]*OptionStats, len(c.Managers))
+	for m := range options {
+		options[m] = make(map[string]*OptionStats)
+	}
+	for s := 0; s < samples; s++ {
+		sc := c.WithValues(model.Sample(rng))
+		result := IteratedBestResponse(sc, opts)
+		profile := result.Profiles[len(result.Profiles)-1]
+		for m := range sc.Managers {
+			all := AllResponses(sc, ManagerID(m), profile)
+			bestI := 0
+			for i, au := range all {
+				if au.Utility > all[bestI].Utility {
+					bestI = i
+				}
+				gids := au.Act.SortedGIDs()
+				key := fmt.Sprint(gids)
+				o, ok := options[m][key]
+				if !ok {
+					o = &OptionStats{GIDs: gids}
+					options[m][key] = o
+				}
+				o.Utilities = append(o.Utilities, au.Utility)
+			}
+			options[m][fmt.Sprint(all[bestI].Act.SortedGIDs())].Best++
+		}
+	}
+
+	result := make([][]*OptionStats, len(c.Managers))
+	for m := range options {
+		for _, o := range options[m] {
+			result[m] = append(result[m], o)
+		}
+		sort.Slice(result[m], func(i, j int) bool { return result[m][i].Mean() > result[m][j].Mean() })
+	}
+	return result
+}
+
+// SortedGIDs returns the kept gridders in ascending order.
+func (a Action) SortedGIDs() []GridderID {
+	var gids []GridderID
+	for _, k := range a {
+		gids = append(gids, k.GID)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	return gids
+}
+
+// GridderNames returns the names of the gridders joined with " + ", or
+// "(none)".
+func (c *Constants) GridderNames(gids []GridderID) string {
+	if len(gids) == 0 {
+		return "(none)"
+	}
+	var names []string
+	for _, gid := range gids {
+		names = append(names, c.Gridders[gid].Name)
+	}
+	return strings.Join(names, " + ")
+}
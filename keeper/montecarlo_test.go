@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMonteCarlo(t *testing.T) {
+	values := []float64{100, 90, 50, 40, 30, 20, 10, 5}
+	c := testConstants(2, 3, 1,
+		values,
+		[]ManagerID{0, 1, -1, -1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0, 0, 0})
+	const samples = 50
+	for _, frac := range []float64{0, 0.5} {
+		stddevs := make([]float64, len(values))
+		for i, v := range values {
+			stddevs[i] = frac * v
+		}
+		model := &NormalValues{Mean: values, Stddev: stddevs}
+		options := MonteCarlo(c, model, samples, rand.New(rand.NewSource(1)), &IBROptions{})
+		for m, opts := range options {
+			best := 0
+			for _, o := range opts {
+				best += o.Best
+				if len(o.Utilities) != samples {
+					t.Errorf("frac %v, manager %d: got %d utilities, want %d", frac, m, len(o.Utilities), samples)
+				}
+			}
+			if best != samples {
+				t.Errorf("frac %v, manager %d: best counts sum to %d, want %d", frac, m, best, samples)
+			}
+			spread := opts[0].Quantile(1) - opts[0].Quantile(0)
+			if frac == 0 && spread != 0 {
+				t.Errorf("manager %d: got spread %v with no value noise, want 0", m, spread)
+			}
+			if frac > 0 && spread == 0 {
+				t.Errorf("manager %d: got no spread with value noise", m)
+			}
+		}
+	}
+}
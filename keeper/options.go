@@ -0,0 +1,316 @@
+package keeper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dbtleonia/fantasy/yahoo"
+)
+
+// Option is a rostered player a manager may keep, a row of
+// keeper-options.csv.  Index n-1 of Kept and Dropped is n seasons ago.
+type Option struct {
+	Manager    string
+	Player     string // "Name (Team - Pos)"
+	ID         int
+	Round      int // 0 if unkeepable
+	DraftRound int // last season; 0 if undrafted
+	Reason     string
+	Kept       []bool
+	Dropped    []bool
+}
+
+type draftPick struct {
+	round int
+	kept  bool
+}
+
+// YahooHistory is the Yahoo data needed to compute keeper options for a
+// season.
+type YahooHistory struct {
+	Year     int
+	managers map[string]string // GUID -> display name
+	rosters  *yahoo.League     // end of last season
+	drafts   []map[int]draftPick
+	dropped  map[int]map[int]bool // season -> player IDs
+}
+
+func readLeague(filename string) (*yahoo.League, error) {
+	r, err := yahoo.ReadResponse(filename)
+	if err != nil {
+		return nil, err
+	}
+	if r.FantasyContent.League == nil {
+		return nil, fmt.Errorf("%s: no league", filename)
+	}
+	return r.FantasyContent.League, nil
+}
+
+// playerID returns the numeric ID from a key like nfl.p.30123.
+func playerID(key string) (int, error) {
+	id, err := strconv.Atoi(key[strings.LastIndex(key, ".")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("bad player key %q", key)
+	}
+	return id, nil
+}
+
+// ReadYahooHistory reads from dir the files for the given season:
+// managers-<year>.json, rosters-<year-1>.json, draftresults-<y>.json for
+// each of the previous seasons and drops-<year-1>-...-<year-seasons>.json.
+// Keeper flags come from the is_keeper field of draftresults/players
+// responses.
+func ReadYahooHistory(dir string, year, seasons int) (*YahooHistory, error) {
+	h := &YahooHistory{
+		Year:     year,
+		managers: make(map[string]string),
+		dropped:  make(map[int]map[int]bool),
+	}
+
+	l, err := readLeague(path.Join(dir, fmt.Sprintf("managers-%d.json", year)))
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range l.Teams {
+		if len(t.Team.Managers) == 0 {
+			return nil, fmt.Errorf("managers-%d.json: no manager for team %s", year, t.Team.Name)
+		}
+		m := t.Team.Managers[0].Manager
+		h.managers[m.GUID] = fmt.Sprintf("%s - %s", m.Nickname, t.Team.Name)
+	}
+
+	h.rosters, err = readLeague(path.Join(dir, fmt.Sprintf("rosters-%d.json", year-1)))
+	if err != nil {
+		return nil, err
+	}
+
+	var years []string
+	for n := 1; n <= seasons; n++ {
+		years = append(years, strconv.Itoa(year-n))
+		l, err := readLeague(path.Join(dir, fmt.Sprintf("draftresults-%d.json", year-n)))
+		if err != nil {
+			return nil, err
+		}
+		draft := make(map[int]draftPick)
+		for _, d := range l.DraftResults {
+			dr := d.DraftResult
+			if dr.PlayerKey == "" {
+				continue // pick not made
+			}
+			id, err := playerID(dr.PlayerKey)
+			if err != nil {
+				return nil, err
+			}
+			p := draftPick{round: int(dr.Round)}
+			if dr.Player != nil && dr.Player.IsKeeper != nil {
+				p.kept = bool(dr.Player.IsKeeper.Kept)
+			}
+			draft[id] = p
+		}
+		h.drafts = append(h.drafts, draft)
+	}
+
+	r, err := yahoo.ReadResponse(path.Join(dir, fmt.Sprintf("drops-%s.json", strings.Join(years, "-"))))
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range r.FantasyContent.Leagues {
+		season := int(l.League.Season)
+		h.dropped[season] = make(map[int]bool)
+		for _, t := range l.League.Transactions {
+			for _, p := range t.Transaction.Players {
+				if p.Player.TransactionData != nil && p.Player.TransactionData.Type == "drop" {
+					h.dropped[season][int(p.Player.PlayerID)] = true
+				}
+			}
+		}
+	}
+	return h, nil
+}
+
+// ComputeOptions applies the keeper rules to every rostered player and
+// returns the options sorted by manager, then keeper round with
+// unkeepables last.  The rules may not refer to more seasons than h
+// has.
+func ComputeOptions(h *YahooHistory, rules []*Rule) ([]*Option, error) {
+	if n := Seasons(rules); n > len(h.drafts) {
+		return nil, fmt.Errorf("rules need %d seasons of history, have %d", n, len(h.drafts))
+	}
+	var options []*Option
+	names := make(map[string]bool)
+	for _, t := range h.rosters.Teams {
+		var guid string
+		if len(t.Team.Managers) > 0 {
+			guid = t.Team.Managers[0].Manager.GUID
+		}
+		managerName, ok := h.managers[guid]
+		if !ok {
+			return nil, fmt.Errorf("no manager for team %s", t.Team.Name)
+		}
+		for _, p := range t.Team.Players {
+			pl := p.Player
+			id := int(pl.PlayerID)
+			o := &Option{
+				Manager:    managerName,
+				Player:     fmt.Sprintf("%s (%s - %s)", pl.Name.Full, pl.EditorialTeamAbbr, pl.DisplayPosition),
+				ID:         id,
+				DraftRound: h.drafts[0][id].round,
+			}
+			if names[o.Player] {
+				return nil, fmt.Errorf("duplicate name: %s", o.Player)
+			}
+			names[o.Player] = true
+			for n := 1; n <= len(h.drafts); n++ {
+				o.Kept = append(o.Kept, h.drafts[n-1][id].kept)
+				o.Dropped = append(o.Dropped, h.dropped[h.Year-n][id])
+			}
+			o.Round, o.Reason = KeeperRound(rules, &History{
+				Position:   pl.PrimaryPosition,
+				DraftRound: o.DraftRound,
+				Kept:       o.Kept,
+				Dropped:    o.Dropped,
+			})
+			options = append(options, o)
+		}
+	}
+
+	sortRound := func(r int) int {
+		if r == 0 {
+			return 1 << 30
+		}
+		return r
+	}
+	sort.Slice(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if a.Manager != b.Manager {
+			return a.Manager < b.Manager
+		}
+		if a.Round != b.Round {
+			return sortRound(a.Round) < sortRound(b.Round)
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		return a.Player < b.Player
+	})
+	return options, nil
+}
+
+// WriteOptions writes keeper-options.csv for the given season.
+func WriteOptions(filename string, year int, options []*Option) error {
+	seasons := 0
+	if len(options) > 0 {
+		seasons = len(options[0].Kept)
+	}
+	yy := func(y int) string { return fmt.Sprintf("%02d", y%100) }
+	header := []string{
+		"Manager",
+		"Player",
+		"Player ID",
+		"Keeper round " + yy(year),
+		"Draft round " + yy(year-1),
+		"Reason",
+	}
+	for n := 1; n <= seasons; n++ {
+		header = append(header, "Dropped "+yy(year-n), "Kept "+yy(year-n))
+	}
+	records := [][]string{header}
+	for _, o := range options {
+		round := "n/a"
+		if o.Round > 0 {
+			round = strconv.Itoa(o.Round)
+		}
+		drafted := ""
+		if o.DraftRound > 0 {
+			drafted = strconv.Itoa(o.DraftRound)
+		}
+		record := []string{o.Manager, o.Player, strconv.Itoa(o.ID), round, drafted, o.Reason}
+		for n := 1; n <= seasons; n++ {
+			d, k := "", ""
+			if o.Dropped[n-1] {
+				d = "D" + yy(year-n)
+			}
+			if o.Kept[n-1] {
+				k = "K" + yy(year-n)
+			}
+			record = append(record, d, k)
+		}
+		records = append(records, record)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadOptions reads keeper-options.csv.  Only the Manager, Player,
+// Round and Kept fields are used by the keeper game; the others are
+// filled in when present.
+func ReadOptions(filename string) ([]*Option, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("keeper options: %s", err)
+	}
+
+	// Columns like "Kept 23", most recent season first.
+	var keptCols, droppedCols []int
+	for i, h := range records[0] {
+		if strings.HasPrefix(h, "Kept ") {
+			keptCols = append(keptCols, i)
+		}
+		if strings.HasPrefix(h, "Dropped ") {
+			droppedCols = append(droppedCols, i)
+		}
+	}
+
+	var options []*Option
+	for _, record := range records[1:] { // skip header
+		o := &Option{
+			Manager: record[0],
+			Player:  record[1],
+		}
+		o.ID, err = strconv.Atoi(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("keeper options: %s", err)
+		}
+		if record[3] != "n/a" {
+			o.Round, err = strconv.Atoi(record[3])
+			if err != nil {
+				return nil, fmt.Errorf("keeper options: %s", err)
+			}
+		}
+		if record[4] != "" { // undrafted
+			o.DraftRound, err = strconv.Atoi(record[4])
+			if err != nil {
+				return nil, fmt.Errorf("keeper options: %s", err)
+			}
+		}
+		if len(record) > 5 {
+			o.Reason = record[5]
+		}
+		for _, i := range keptCols {
+			o.Kept = append(o.Kept, record[i] != "")
+		}
+		for _, i := range droppedCols {
+			o.Dropped = append(o.Dropped, record[i] != "")
+		}
+		options = append(options, o)
+	}
+	return options, nil
+}
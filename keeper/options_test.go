@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestComputeOptions(t *testing.T) {
+	h, err := ReadYahooHistory(path.Join("testdata", "history"), 2025, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := CompileRules(fantasy.DefaultKeeperRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ComputeOptions(h, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rounds and reasons are those of compute_keeper_round in the old
+	// make_options.py.
+	y, n := true, false
+	want := []*Option{
+		{"Al - Aces", "Plain Pick (Cin - WR)", 4, 5, 5, "", []bool{n, n, n}, []bool{n, n, n}},
+		{"Al - Aces", "Dropped Guy (Min - WR)", 3, 7, 5, "dropped", []bool{n, n, n}, []bool{y, n, n}},
+		{"Al - Aces", "Undrafted Guy (KC - TE)", 2, 9, 0, "undrafted", []bool{n, n, n}, []bool{n, n, n}},
+		{"Al - Aces", "Kept Thrice (Buf - QB)", 1, 0, 5, "kept 3 years non-D", []bool{y, y, y}, []bool{n, n, n}},
+		{"Bo - Bombers", "Chicago (Chi - DEF)", 7, 4, 5, "kept", []bool{y, y, y}, []bool{n, n, n}},
+		{"Bo - Bombers", "Once Kept (Bal - RB)", 5, 4, 5, "kept", []bool{y, n, n}, []bool{n, n, n}},
+		{"Bo - Bombers", "Saquon (Phi - QB)", 9, 7, 5, "dropped/saquon", []bool{y, y, y}, []bool{y, n, n}},
+		{"Bo - Bombers", "Dropped Undrafted (NE - TE)", 8, 11, 0, "undrafted/dropped", []bool{n, n, n}, []bool{y, n, n}},
+		{"Bo - Bombers", "Round One (SF - RB)", 6, 0, 1, "kept round 1", []bool{y, n, n}, []bool{n, n, n}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("got %+v", got[i])
+		}
+		t.Fatalf("ComputeOptions doesn't match make_options.py")
+	}
+
+	filename := path.Join(t.TempDir(), "keeper-options.csv")
+	if err := WriteOptions(filename, 2025, got); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadOptions(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, want) {
+		for i := range read {
+			t.Logf("read %+v", read[i])
+		}
+		t.Errorf("ReadOptions(WriteOptions(options)) differs")
+	}
+
+	// The default rules look back 3 seasons.
+	h.drafts = h.drafts[:2]
+	if _, err := ComputeOptions(h, rules); err == nil {
+		t.Errorf("ComputeOptions with 2 seasons of history succeeded, want error")
+	}
+}
+
+func TestReadOptionsErrors(t *testing.T) {
+	header := "Manager,Player,Player ID,Keeper round 25,Draft round 24,Reason\n"
+	tests := []struct {
+		desc string
+		row  string
+	}{
+		{"bad id", "Al,X (KC - QB),x,5,5,\n"},
+		{"bad round", "Al,X (KC - QB),1,five,5,\n"},
+		{"bad draft round", "Al,X (KC - QB),1,5,five,\n"},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		filename := path.Join(dir, strings.ReplaceAll(test.desc, " ", "-")+".csv")
+		if err := os.WriteFile(filename, []byte(header+test.row), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadOptions(filename); err == nil {
+			t.Errorf("%s: ReadOptions succeeded, want error", test.desc)
+		}
+	}
+}
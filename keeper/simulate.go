@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+// Simulator scores keeper profiles by simulating the rest of the draft
+// with Humanoid strategies and scoring each manager's lineup, averaged
+// over trials.  The same sampled ADP rankings are used for every
+// profile, so profiles are compared on equal terms.  Since best
+// responses evaluate the same profiles many times, each profile's
+// result is cached.
+type Simulator struct {
+	players  []*fantasy.Player
+	playerOf map[GridderID]int // gid -> player ID
+	order    []int             // picks start at 1
+	rules    *fantasy.Rules
+	scorer   *fantasy.Scorer
+	rankings [][][]fantasy.PlayerADP // trial -> manager -> players by ADP
+
+	mu    sync.Mutex
+	cache map[string][]float64 // profileKey -> utilities
+}
+
+// profileKey identifies a profile by its keeps, which is enough since
+// each gridder has one owner.
+func profileKey(actions []Action) string {
+	var keeps []string
+	for _, a := range actions {
+		for _, k := range a {
+			keeps = append(keeps, k.String())
+		}
+	}
+	sort.Strings(keeps)
+	return strings.Join(keeps, " ")
+}
+
+// playerKey returns the name used to match players to gridders, in the
+// gridder format "Name (Team - Pos)".
+func playerKey(name string) string {
+	name = strings.Replace(name, " - DEF)", " - DST)", 1)
+	return strings.ToUpper(name)
+}
+
+// NewSimulator matches every owned gridder to a player by name and
+// samples ADP rankings for each trial.  Players must not have picks.
+func NewSimulator(c *Constants, players []*fantasy.Player, rules *fantasy.Rules, scorer *fantasy.Scorer, trials int, rng *rand.Rand) (*Simulator, error) {
+	ids := make(map[string]int)
+	for _, p := range players {
+		if p.Pick != 0 {
+			return nil, fmt.Errorf("player %s already has pick %d", p.Name, p.Pick)
+		}
+		ids[playerKey(fmt.Sprintf("%s (%s - %s)", p.Name, p.Team, p.Pos))] = p.ID
+	}
+	playerOf := make(map[GridderID]int)
+	var missing []string
+	for gid, g := range c.Gridders {
+		if g.MID == -1 {
+			continue
+		}
+		id, ok := ids[playerKey(g.Name)]
+		if !ok {
+			missing = append(missing, g.Name)
+			continue
+		}
+		playerOf[GridderID(gid)] = id
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no player for gridders:\n  %s", strings.Join(missing, "\n  "))
+	}
+
+	rankings := make([][][]fantasy.PlayerADP, trials)
+	for t := range rankings {
+		rankings[t] = make([][]fantasy.PlayerADP, len(c.Managers))
+		for m := range rankings[t] {
+			for _, p := range players {
+				rankings[t][m] = append(rankings[t][m], fantasy.PlayerADP{
+					PlayerID: p.ID,
+					ADP:      rng.NormFloat64()*p.Stddev + p.ADP,
+				})
+			}
+			ranked := rankings[t][m]
+			sort.Slice(ranked, func(i, j int) bool { return ranked[i].ADP < ranked[j].ADP })
+		}
+	}
+
+	s := &Simulator{players: players, playerOf: playerOf, rules: rules, scorer: scorer, rankings: rankings}
+	return s.withPicks(c.picks), nil
+}
+
+// UtilityAll returns each manager's average lineup score.  The result
+// must not be modified.
+func (s *Simulator) UtilityAll(actions []Action) []float64 {
+	key := profileKey(actions)
+	s.mu.Lock()
+	result, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok {
+		return result
+	}
+	result = s.simulate(actions)
+	s.mu.Lock()
+	s.cache[key] = result
+	s.mu.Unlock()
+	return result
+}
+
+// simulate runs every trial's draft for the profile.
+func (s *Simulator) simulate(actions []Action) []float64 {
+	keepPicks := make(map[int]int) // player ID -> pick
+	for _, a := range actions {
+		for _, k := range a {
+			keepPicks[s.playerOf[k.GID]] = k.Pick + 1
+		}
+	}
+	players := make([]*fantasy.Player, len(s.players))
+	for i, p := range s.players {
+		player := *p
+		player.Pick = keepPicks[p.ID]
+		players[i] = &player
+	}
+	start, order := fantasy.NewState(players, len(actions), s.order)
+
+	result := make([]float64, len(actions))
+	for _, ranked := range s.rankings {
+		strategies := make([]fantasy.Strategy, len(actions))
+		for m := range strategies {
+			strategies[m] = fantasy.NewHumanoid(order, s.rules, ranked[m])
+		}
+		state := start.Clone()
+		fantasy.RunDraft(state, order, strategies)
+		for m, team := range state.Teams {
+			result[m] += s.scorer.Score(team)
+		}
+	}
+	for m := range result {
+		result[m] /= float64(len(s.rankings))
+	}
+	return result
+}
+
+// withPicks returns a copy of the simulator using a new draft order,
+// with an empty cache.
+func (s *Simulator) withPicks(picks []ManagerID) *Simulator {
+	order := []int{8888} // dummy as first element; picks start at 1
+	for _, mid := range picks {
+		order = append(order, int(mid))
+	}
+	return &Simulator{
+		players:  s.players,
+		playerOf: s.playerOf,
+		order:    order,
+		rules:    s.rules,
+		scorer:   s.scorer,
+		rankings: s.rankings,
+		cache:    make(map[string][]float64),
+	}
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestSimulator(t *testing.T) {
+	c := testConstants(2, 2, 1, []float64{100}, []ManagerID{0}, []int{2})
+	c.Gridders[0].Name = "Qb (KC - QB)"
+	players := []*fantasy.Player{
+		{ID: 0, Name: "Qb", Pos: "QB", Team: "KC", Points: 100, ADP: 1, Stddev: 0},
+		{ID: 1, Name: "Rb", Pos: "RB", Team: "NE", Points: 90, ADP: 2, Stddev: 0},
+		{ID: 2, Name: "Wr", Pos: "WR", Team: "Bal", Points: 80, ADP: 3, Stddev: 0},
+		{ID: 3, Name: "Te", Pos: "TE", Team: "Cin", Points: 70, ADP: 4, Stddev: 0},
+		{ID: 4, Name: "K", Pos: "K", Team: "KC", Points: 10, ADP: 5, Stddev: 0},
+	}
+	all := map[byte]bool{'Q': true, 'R': true, 'W': true, 'T': true, 'K': true}
+	rules := &fantasy.Rules{HumanoidMap: make(map[string]map[byte]bool)}
+	for _, roster := range []string{"", "Q", "R", "T", "W"} {
+		rules.HumanoidMap[roster] = all
+	}
+	scorer := fantasy.NewScorer([]byte("XX"), false, map[byte]string{'X': "QRWT"})
+
+	s, err := NewSimulator(c, players, rules, scorer, 3, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		actions []Action
+		want    []float64
+	}{
+		// Picks go A B B A.
+		{"no keepers", []Action{nil, nil}, []float64{170, 170}},
+		{"A keeps Qb last", []Action{{{Pick: 3, GID: 0}}, nil}, []float64{190, 150}},
+	}
+	for _, tt := range tests {
+		if got := s.UtilityAll(tt.actions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: UtilityAll = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := s.UtilityAll(tt.actions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: cached UtilityAll = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got, want := len(s.cache), len(tests); got != want {
+		t.Errorf("len(cache) = %d, want %d", got, want)
+	}
+
+	// Picks go B A A B.
+	r := s.withPicks([]ManagerID{1, 0, 0, 1})
+	if got := len(r.cache); got != 0 {
+		t.Errorf("withPicks: len(cache) = %d, want 0", got)
+	}
+	if got, want := r.UtilityAll([]Action{{{Pick: 2, GID: 0}}, nil}), []float64{180, 160}; !reflect.DeepEqual(got, want) {
+		t.Errorf("withPicks: UtilityAll = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Swap exchanges the owners of two picks, numbered from 0.
+type Swap struct {
+	A, B int
+}
+
+// PickOwner returns the manager who owns a pick, numbered from 0.
+func (c *Constants) PickOwner(pick int) ManagerID {
+	return c.picks[pick]
+}
+
+// NumPicks returns the number of picks in the draft.
+func (c *Constants) NumPicks() int {
+	return len(c.picks)
+}
+
+// WithTrade returns a copy of the constants with the swaps applied.
+// Traded picks count as acquired via trade, so they can't be used for
+// keeping.
+func (c *Constants) WithTrade(swaps []Swap) (*Constants, error) {
+	picks := append([]ManagerID(nil), c.picks...)
+	viaTrade := append([]bool(nil), c.viaTrade...)
+	for _, s := range swaps {
+		if s.A < 0 || s.A >= len(picks) || s.B < 0 || s.B >= len(picks) {
+			return nil, fmt.Errorf("no such pick in swap %d, %d", s.A+1, s.B+1)
+		}
+		if picks[s.A] == picks[s.B] {
+			return nil, fmt.Errorf("picks %d and %d have the same owner", s.A+1, s.B+1)
+		}
+		picks[s.A], picks[s.B] = picks[s.B], picks[s.A]
+		viaTrade[s.A] = true
+		viaTrade[s.B] = true
+	}
+
+	gridders := make([]*Gridder, len(c.Gridders))
+	for i, g := range c.Gridders {
+		gridder := *g
+		gridders[i] = &gridder
+	}
+	setAllowedPicks(gridders, len(c.Managers), picks, viaTrade)
+
+	result := *c
+	result.Gridders = gridders
+	result.picks = picks
+	result.viaTrade = viaTrade
+	if c.Simulator != nil {
+		result.Simulator = c.Simulator.withPicks(picks)
+	}
+	if c.beliefs != nil {
+		result.beliefs = make([]*Constants, len(c.beliefs))
+		for m, b := range c.beliefs {
+			if b != nil {
+				result.beliefs[m], _ = b.WithTrade(swaps) // same picks, so no error
+			}
+		}
+	}
+	return &result, nil
+}
+
+// TradeResult is the change in each manager's equilibrium utility from
+// a trade.
+type TradeResult struct {
+	Swaps  []Swap
+	Before []float64
+	After  []float64
+	Result *IBRResult // solved with the trade
+}
+
+func (t *TradeResult) Change(mid ManagerID) float64 {
+	return t.After[mid] - t.Before[mid]
+}
+
+// finalUtilities returns each manager's utility in the last profile.
+func finalUtilities(c *Constants, r *IBRResult) []float64 {
+	return UtilityAll(c, r.Profiles[len(r.Profiles)-1])
+}
+
+// allowedKeeps returns a copy of the profile without keeps at picks the
+// gridder can no longer be kept at.
+func (c *Constants) allowedKeeps(actions []Action) []Action {
+	result := make([]Action, len(actions))
+	for m, a := range actions {
+		for _, k := range a {
+			for _, pick := range c.Gridders[k.GID].Picks {
+				if pick == k.Pick {
+					result[m] = append(result[m], k)
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+// EvaluateTrade re-solves the keeper game with the swaps applied and
+// compares each manager's utility to the given solution without them.
+// Unless opts has a Start, the new solution starts from the old one,
+// since a trade usually changes few keeps.
+func EvaluateTrade(c *Constants, before *IBRResult, swaps []Swap, opts *IBROptions) (*TradeResult, error) {
+	traded, err := c.WithTrade(swaps)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Start == nil {
+		warm := *opts
+		warm.Start = traded.allowedKeeps(before.Profiles[len(before.Profiles)-1])
+		opts = &warm
+	}
+	after := IteratedBestResponse(traded, opts)
+	return &TradeResult{
+		Swaps:  swaps,
+		Before: finalUtilities(c, before),
+		After:  finalUtilities(traded, after),
+		Result: after,
+	}, nil
+}
+
+// RankTrades evaluates every one-for-one swap of a pick owned by mid
+// for another manager's pick at most maxGap rounds away, and returns
+// them sorted by mid's gain, best first.
+func RankTrades(c *Constants, mid ManagerID, maxGap int, opts *IBROptions) ([]*TradeResult, error) {
+	before := IteratedBestResponse(c, opts)
+	numManagers := len(c.Managers)
+	var result []*TradeResult
+	for a, owner := range c.picks {
+		if owner != mid {
+			continue
+		}
+		for b, other := range c.picks {
+			if other == mid {
+				continue
+			}
+			if gap := a/numManagers - b/numManagers; gap > maxGap || gap < -maxGap {
+				continue
+			}
+			t, err := EvaluateTrade(c, before, []Swap{{a, b}}, opts)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, t)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Change(mid) > result[j].Change(mid)
+	})
+	return result, nil
+}
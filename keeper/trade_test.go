@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithTrade(t *testing.T) {
+	// Picks 0..5 in snake order: A B B A A B.
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90},
+		[]ManagerID{0, 1},
+		[]int{3, 3})
+	if got, want := c.Gridders[0].Picks, []int{4, 3, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("before trade: A's picks = %v, want %v", got, want)
+	}
+
+	traded, err := c.WithTrade([]Swap{{0, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traded.PickOwner(0) != 1 || traded.PickOwner(1) != 0 {
+		t.Errorf("owners of picks 0, 1 = %d, %d; want 1, 0", traded.PickOwner(0), traded.PickOwner(1))
+	}
+	// Neither traded pick can be used for keeping.
+	if got, want := traded.Gridders[0].Picks, []int{4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after trade: A's picks = %v, want %v", got, want)
+	}
+	if got, want := traded.Gridders[1].Picks, []int{5, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after trade: B's picks = %v, want %v", got, want)
+	}
+	// The original is unchanged.
+	if c.PickOwner(0) != 0 || len(c.Gridders[0].Picks) != 3 {
+		t.Errorf("original constants modified")
+	}
+
+	if _, err := c.WithTrade([]Swap{{1, 2}}); err == nil {
+		t.Errorf("swap of picks with the same owner succeeded, want error")
+	}
+	if _, err := c.WithTrade([]Swap{{0, 6}}); err == nil {
+		t.Errorf("swap of nonexistent pick succeeded, want error")
+	}
+}
+
+func TestEvaluateTradeWarmStart(t *testing.T) {
+	// Picks 0..5 in snake order: A B B A A B.
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40, 30, 20},
+		[]ManagerID{0, 1, -1, -1, -1, -1},
+		[]int{3, 3, 0, 0, 0, 0})
+	opts := &IBROptions{}
+	before := IteratedBestResponse(c, opts)
+	swaps := []Swap{{3, 2}}
+	traded, err := c.WithTrade(swaps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keeps at traded picks are dropped from the start.
+	start := traded.allowedKeeps([]Action{{{Pick: 3, GID: 0}}, {{Pick: 5, GID: 1}}})
+	if got, want := start, []Action{nil, {{Pick: 5, GID: 1}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("allowedKeeps = %v, want %v", got, want)
+	}
+
+	warm, err := EvaluateTrade(c, before, swaps, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cold := IteratedBestResponse(traded, opts)
+	if got, want := warm.After, finalUtilities(traded, cold); !reflect.DeepEqual(got, want) {
+		t.Errorf("warm start After = %v, cold start = %v", got, want)
+	}
+	if opts.Start != nil {
+		t.Errorf("EvaluateTrade modified opts.Start")
+	}
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problems is a list of problems reported together as one error.
+type Problems []string
+
+func (p Problems) Error() string {
+	return strings.Join(p, "\n")
+}
+
+// Validate checks that each manager keeps at most the maximum number of
+// gridders, only gridders on their roster, and only with their own
+// picks, not acquired via trade, at or before each gridder's keeper
+// round.  It returns all violations as Problems, or nil.
+func Validate(c *Constants, actions []Action) error {
+	numManagers := len(c.Managers)
+	pickString := func(pick int) string {
+		return fmt.Sprintf("%d-%d", pick/numManagers+1, pick+1)
+	}
+
+	var problems Problems
+	for m, action := range actions {
+		mid := ManagerID(m)
+		name := c.Managers[mid].Name
+		if len(action) > c.maxKeepers {
+			problems = append(problems, fmt.Sprintf("%s: keeps %d, max is %d", name, len(action), c.maxKeepers))
+		}
+		picks := make(map[int]bool)
+		gids := make(map[GridderID]bool)
+		for _, k := range action {
+			gridder := c.Gridders[k.GID]
+			prefix := fmt.Sprintf("%s: %s", name, gridder.Name)
+			if gids[k.GID] {
+				problems = append(problems, fmt.Sprintf("%s: kept more than once", prefix))
+			}
+			gids[k.GID] = true
+			if gridder.MID != mid {
+				problems = append(problems, fmt.Sprintf("%s: not on roster", prefix))
+			}
+			if k.Pick < 0 || k.Pick >= len(c.picks) {
+				problems = append(problems, fmt.Sprintf("%s: no pick %d", prefix, k.Pick+1))
+				continue
+			}
+			prefix = fmt.Sprintf("%s at %s", prefix, pickString(k.Pick))
+			if picks[k.Pick] {
+				problems = append(problems, fmt.Sprintf("%s: pick used more than once", prefix))
+			}
+			picks[k.Pick] = true
+			if owner := c.picks[k.Pick]; owner != mid {
+				problems = append(problems, fmt.Sprintf("%s: pick owned by %s", prefix, c.Managers[owner].Name))
+			} else if c.viaTrade[k.Pick] {
+				problems = append(problems, fmt.Sprintf("%s: pick acquired via trade", prefix))
+			}
+			if gridder.MID == mid {
+				if gridder.Round == 0 {
+					problems = append(problems, fmt.Sprintf("%s: not keepable", prefix))
+				} else if round := k.Pick/numManagers + 1; round > gridder.Round {
+					problems = append(problems, fmt.Sprintf("%s: round %d is after keeper round %d", prefix, round, gridder.Round))
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
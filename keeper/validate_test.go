@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	// Picks 0..5 in snake order: A B B A A B.  Gridder c is unowned and
+	// d is not keepable.
+	c := testConstants(2, 3, 1,
+		[]float64{100, 90, 50, 40},
+		[]ManagerID{0, 1, -1, 0},
+		[]int{2, 3, 0, 0})
+	traded, err := c.WithTrade([]Swap{{0, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc    string
+		c       *Constants
+		actions []Action
+		want    Problems
+	}{
+		{"valid", c, []Action{{{Pick: 3, GID: 0}}, {{Pick: 5, GID: 1}}}, nil},
+		{"nobody", c, []Action{nil, nil}, nil},
+		{"too many", c, []Action{{{Pick: 3, GID: 0}, {Pick: 4, GID: 3}}, nil}, Problems{
+			"A: keeps 2, max is 1",
+			"A: d at 3-5: not keepable",
+		}},
+		{"not on roster", c, []Action{{{Pick: 0, GID: 2}}, nil}, Problems{
+			"A: c: not on roster",
+		}},
+		{"not owner", c, []Action{{{Pick: 1, GID: 0}}, nil}, Problems{
+			"A: a at 1-2: pick owned by B",
+		}},
+		{"after keeper round", c, []Action{{{Pick: 4, GID: 0}}, nil}, Problems{
+			"A: a at 3-5: round 3 is after keeper round 2",
+		}},
+		{"via trade", traded, []Action{{{Pick: 1, GID: 0}}, nil}, Problems{
+			"A: a at 1-2: pick acquired via trade",
+		}},
+		{"no such pick", c, []Action{{{Pick: 6, GID: 0}}, nil}, Problems{
+			"A: a: no pick 7",
+		}},
+		{"all together", c, []Action{{{Pick: 1, GID: 0}}, {{Pick: 1, GID: 1}, {Pick: 2, GID: 1}}}, Problems{
+			"A: a at 1-2: pick owned by B",
+			"B: keeps 2, max is 1",
+			"B: b: kept more than once",
+		}},
+	}
+	for _, test := range tests {
+		err := Validate(test.c, test.actions)
+		var got Problems
+		if err != nil {
+			got = err.(Problems)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package fantasy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// League holds the settings shared by the draft, keeper and Yahoo
+// commands.
+type League struct {
+	Teams      int               `json:"teams"`
+	Rounds     int               `json:"rounds"`
+	Schema     string            `json:"schema"` // eg QRRWWWTXDKBBBBBBBB
+	Flex       map[string]string `json:"flex"`   // schema letter -> allowed positions, eg X -> RTW
+	MaxKeepers int               `json:"max_keepers"`
+	Scoring    Scoring           `json:"scoring"`
+
+	// Evaluated in order; the first matching rule sets a player's
+	// keeper round.  Empty means DefaultKeeperRules.
+	KeeperRules []KeeperRule `json:"keeper_rules"`
+
+	// Data paths; empty means the default noted on each.
+	DataDir     string `json:"data_dir"`     // keeper data, default $HOME/data
+	YahooDir    string `json:"yahoo_dir"`    // downloaded Yahoo files, default $HOME
+	YahooLeague string `json:"yahoo_league"` // league key, default from $HOME/league.txt
+}
+
+// KeeperRule sets the keeper round of players matching When, a list of
+// space separated terms that must all hold.  Terms, each of which may
+// be negated with a leading "!", are:
+//
+//	Kn          kept n seasons ago, eg K1 for last season
+//	Dn          dropped n seasons ago
+//	UNDRAFTED   not drafted last season
+//	POS=p       primary position is p, eg POS=DEF
+//	R<op>n      drafted last season in round R, eg R>1 or R<=3; op is
+//	            one of = != < <= > >=
+//
+// Round is "n/a" for unkeepable, a fixed round such as "9", or an offset
+// from last season's draft round such as "R", "R+2" or "R-1".  A
+// computed round is capped at Max if Max is nonzero.
+type KeeperRule struct {
+	When   string `json:"when"`
+	Round  string `json:"round"`
+	Max    int    `json:"max,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// DefaultKeeperRules are the keeper rules of the default league.  A
+// dropped player who would otherwise be unkeepable is flagged for a
+// manual check.
+var DefaultKeeperRules = []KeeperRule{
+	{When: "K1 K2 K3 !D1 !D2 !D3 !POS=DEF", Round: "n/a", Reason: "kept 3 years non-D"},
+	{When: "UNDRAFTED D1", Round: "11", Reason: "undrafted/dropped"},
+	{When: "UNDRAFTED", Round: "9", Reason: "undrafted"},
+	{When: "D1 K1 K2 K3 !D2 !D3 !POS=DEF", Round: "R+2", Max: 16, Reason: "dropped/saquon"},
+	{When: "D1 R=1", Round: "R+2", Max: 16, Reason: "dropped/saquon"},
+	{When: "D1", Round: "R+2", Max: 16, Reason: "dropped"},
+	{When: "!K1", Round: "R", Reason: ""},
+	{When: "R>1", Round: "R-1", Reason: "kept"},
+	{When: "", Round: "n/a", Reason: "kept round 1"},
+}
+
+// DefaultLeague returns the settings used when no league config is
+// given.
+func DefaultLeague() *League {
+	return &League{
+		Teams:       12,
+		Rounds:      18,
+		Schema:      "QRRWWWTXDKBBBBBBBB",
+		Flex:        map[string]string{"X": "R"},
+		MaxKeepers:  3,
+		KeeperRules: append([]KeeperRule(nil), DefaultKeeperRules...),
+	}
+}
+
+// LoadLeague reads the league config from filename, or returns the
+// default league if filename is empty.
+func LoadLeague(filename string) (*League, error) {
+	if filename == "" {
+		return DefaultLeague(), nil
+	}
+	return ReadLeague(filename)
+}
+
+// Dir returns the directory for keeper data files.
+func (l *League) Dir() string {
+	if l.DataDir != "" {
+		return l.DataDir
+	}
+	home, _ := os.UserHomeDir()
+	return path.Join(home, "data")
+}
+
+// EligibilityRules returns the league's keeper rules.
+func (l *League) EligibilityRules() []KeeperRule {
+	if len(l.KeeperRules) == 0 {
+		return DefaultKeeperRules
+	}
+	return l.KeeperRules
+}
+
+// Scorer returns a Scorer for the league's roster schema.  The league
+// must be valid.
+func (l *League) Scorer(bench bool) *Scorer {
+	var flex map[byte]string
+	if l.Flex != nil {
+		flex = make(map[byte]string)
+		for ch, allowed := range l.Flex {
+			flex[ch[0]] = allowed
+		}
+	}
+	return NewScorer([]byte(l.Schema), bench, flex)
+}
+
+// Validate returns an error if the league's sizes aren't positive, the
+// schema doesn't have a slot per round, a flex key isn't a single
+// letter, or a schema letter is neither a position, B nor a flex key.
+func (l *League) Validate() error {
+	if l.Teams <= 0 {
+		return fmt.Errorf("teams is %d, want > 0", l.Teams)
+	}
+	if l.Rounds <= 0 {
+		return fmt.Errorf("rounds is %d, want > 0", l.Rounds)
+	}
+	if l.MaxKeepers <= 0 {
+		return fmt.Errorf("max_keepers is %d, want > 0", l.MaxKeepers)
+	}
+	if len(l.Schema) != l.Rounds {
+		return fmt.Errorf("schema %q has %d slots for %d rounds", l.Schema, len(l.Schema), l.Rounds)
+	}
+	for ch := range l.Flex {
+		if len(ch) != 1 {
+			return fmt.Errorf("flex key %q is not a single letter", ch)
+		}
+	}
+	flex := l.Flex
+	if flex == nil {
+		flex = map[string]string{"X": defaultFlex['X']}
+	}
+	for _, ch := range l.Schema {
+		if strings.ContainsRune(schemaPositions, ch) {
+			continue
+		}
+		if _, ok := flex[string(ch)]; !ok {
+			return fmt.Errorf("schema letter %q is not a position or flex key", ch)
+		}
+	}
+	return nil
+}
+
+// schemaPositions are the schema letters other than flex: the first
+// letter of each position, with D for DST, and B for bench.
+const schemaPositions = "QRWTKDB"
+
+// ReadLeague reads a league config and validates it.
+func ReadLeague(filename string) (*League, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var l League
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return &l, nil
+}
+
+func WriteLeague(l *League, filename string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, []byte("\n")...)
+	return os.WriteFile(filename, b, 0644)
+}
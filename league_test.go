@@ -0,0 +1,111 @@
+package fantasy
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestLoadLeague(t *testing.T) {
+	l, err := LoadLeague("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(l, DefaultLeague()) {
+		t.Errorf("LoadLeague(\"\") = %+v, want DefaultLeague", l)
+	}
+
+	tests := []struct {
+		name    string
+		json    string
+		want    *League
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			json: `{"teams": 10, "rounds": 15, "schema": "QRRWWTXYKDBBBBB", "flex": {"X": "RW", "Y": "RTW"}, "max_keepers": 2}`,
+			want: &League{
+				Teams:      10,
+				Rounds:     15,
+				Schema:     "QRRWWTXYKDBBBBB",
+				Flex:       map[string]string{"X": "RW", "Y": "RTW"},
+				MaxKeepers: 2,
+			},
+		},
+		{
+			name: "default flex",
+			json: `{"teams": 10, "rounds": 4, "schema": "QRXB", "max_keepers": 2}`,
+			want: &League{Teams: 10, Rounds: 4, Schema: "QRXB", MaxKeepers: 2},
+		},
+		{name: "no teams", json: `{"rounds": 4, "schema": "QRWB", "max_keepers": 2}`, wantErr: true},
+		{name: "no rounds", json: `{"teams": 10, "max_keepers": 2}`, wantErr: true},
+		{name: "no keepers", json: `{"teams": 10, "rounds": 4, "schema": "QRWB"}`, wantErr: true},
+		{name: "empty flex key", json: `{"teams": 10, "rounds": 4, "schema": "QRWB", "max_keepers": 2, "flex": {"": "R"}}`, wantErr: true},
+		{name: "long flex key", json: `{"teams": 10, "rounds": 4, "schema": "QRWB", "max_keepers": 2, "flex": {"XY": "R"}}`, wantErr: true},
+		{name: "schema too short", json: `{"teams": 10, "rounds": 5, "schema": "QRWB", "max_keepers": 2}`, wantErr: true},
+		{name: "schema too long", json: `{"teams": 10, "rounds": 3, "schema": "QRWB", "max_keepers": 2}`, wantErr: true},
+		{name: "unknown letter", json: `{"teams": 10, "rounds": 4, "schema": "QRYB", "max_keepers": 2, "flex": {"X": "RW"}}`, wantErr: true},
+		{name: "no default flex", json: `{"teams": 10, "rounds": 4, "schema": "QRXB", "max_keepers": 2, "flex": {"Y": "RW"}}`, wantErr: true},
+		{name: "bad json", json: `{"teams": "ten"}`, wantErr: true},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		filename := path.Join(dir, "league.json")
+		if err := os.WriteFile(filename, []byte(tt.json), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadLeague(filename)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: LoadLeague succeeded, want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: LoadLeague = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScorerFlex(t *testing.T) {
+	players := []*Player{
+		{ID: 0, Pos: "QB", Points: 300},
+		{ID: 1, Pos: "RB", Points: 200},
+		{ID: 2, Pos: "RB", Points: 150},
+		{ID: 3, Pos: "WR", Points: 180},
+		{ID: 4, Pos: "WR", Points: 120},
+		{ID: 5, Pos: "TE", Points: 100},
+	}
+	team := &Team{}
+	for i, p := range players {
+		team.Add(p, i+1, "")
+	}
+
+	tests := []struct {
+		name   string
+		schema string
+		flex   map[string]string
+		want   float64
+	}{
+		// Default flex: X takes an RB only.
+		{"default", "QRWX", nil, 300 + 200 + 180 + 150},
+		{"no rb left", "QRRWX", nil, 300 + 200 + 150 + 180},
+		// X takes the best of WR and TE.
+		{"wr/te", "QRWX", map[string]string{"X": "WT"}, 300 + 200 + 180 + 120},
+		// Flex letters fill in ascending order, so X takes the RB
+		// before Y can.
+		{"two flex", "QRWXY", map[string]string{"X": "RWT", "Y": "RW"}, 300 + 200 + 180 + 150 + 120},
+		{"te only", "QRWY", map[string]string{"Y": "T"}, 300 + 200 + 180 + 100},
+	}
+	for _, tt := range tests {
+		l := &League{Schema: tt.schema, Flex: tt.flex}
+		if got := l.Scorer(false).Score(team); got != tt.want {
+			t.Errorf("%s: Score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -16,12 +16,13 @@ var (
 	numTrials = flag.Int("num_trials", 1000, "number of trials to run for optimize")
 	seed      = flag.Int64("seed", 0, "seed for rand; if 0 uses time")
 	bench     = flag.Bool("bench", false, "score bench (using hardcoded weights)")
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
 )
 
 func main() {
 	flag.Parse()
-	if flag.NArg() != 5 {
-		fmt.Fprintf(os.Stderr, "Usage: %s [<flags>] <order-csv> <players-csv> <rules-csv> <schema> <strategies>", os.Args[0])
+	if flag.NArg() != 4 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [<flags>] <order-csv> <players-csv> <rules-csv> <strategies>", os.Args[0])
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
@@ -37,10 +38,16 @@ func main() {
 		orderCsv       = flag.Arg(0)
 		playersCsv     = flag.Arg(1)
 		rulesCsv       = flag.Arg(2)
-		schema         = flag.Arg(3)
-		strategyString = flag.Arg(4)
+		strategyString = flag.Arg(3)
 		numTeams       = len(strategyString)
 	)
+	league, err := fantasy.LoadLeague(*leagueCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if numTeams != league.Teams {
+		log.Fatalf("Got %d strategies, want one per team (%d)", numTeams, league.Teams)
+	}
 	rawOrder, err := fantasy.ReadOrder(orderCsv)
 	if err != nil {
 		log.Fatal(err)
@@ -86,7 +93,7 @@ func main() {
 		return optStrategies
 	}
 
-	scorer := &fantasy.Scorer{[]byte(schema), *bench}
+	scorer := league.Scorer(*bench)
 
 	// Use optimize for the next pick regardless of what the strategies
 	// arg says.
@@ -15,7 +15,8 @@ type Player struct {
 	ID     int
 	Name   string
 	Pos    string // QB RB WR TE K DST
-	Team   string
+	Team   string // canonical, see CanonTeam
+	Bye    int    // 0 if unknown
 	Points float64
 	ADP    float64
 	Stddev float64 // ADP stddev
@@ -48,6 +49,7 @@ func ReadPlayers(filename string) ([]*Player, error) {
 		colPoints = 5
 		colADP    = 6
 		colStddev = 7
+		colBye    = 8 // optional
 	)
 	f, err := os.Open(filename)
 	if err != nil {
@@ -83,11 +85,19 @@ func ReadPlayers(filename string) ([]*Player, error) {
 		if err != nil {
 			return nil, err
 		}
+		bye := 0
+		if len(record) > colBye {
+			bye, err = strconv.Atoi(record[colBye])
+			if err != nil {
+				return nil, err
+			}
+		}
 		players = append(players, &Player{
 			Pick:   pick,
 			ID:     id,
 			Name:   record[colName],
 			Team:   record[colTeam],
+			Bye:    bye,
 			Points: points,
 			Pos:    record[colPos],
 			ADP:    adp,
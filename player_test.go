@@ -0,0 +1,42 @@
+package fantasy
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestReadPlayersBye(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		wantBye int
+		wantErr bool
+	}{
+		{"bye", "0,1,Patrick Mahomes,QB,KC,350.5,12.3,4.1,6\n", 6, false},
+		{"no bye column", "0,1,Patrick Mahomes,QB,KC,350.5,12.3,4.1\n", 0, false},
+		{"bad bye", "0,1,Patrick Mahomes,QB,KC,350.5,12.3,4.1,six\n", 0, true},
+	}
+	dir := t.TempDir()
+	for i, tt := range tests {
+		filename := path.Join(dir, string(rune('a'+i))+".csv")
+		if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		players, err := ReadPlayers(filename)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: ReadPlayers succeeded, want error", tt.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.desc, err)
+			continue
+		}
+		p := players[0]
+		if p.Team != "KC" || p.Bye != tt.wantBye || p.Points != 350.5 {
+			t.Errorf("%s: got %+v, want team KC, bye %d, points 350.5", tt.desc, p, tt.wantBye)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package fantasy
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+type Game struct {
+	Week int
+	Away string // canonical team
+	Home string // canonical team
+}
+
+// ReadSchedule reads a season schedule.  The file has a header row
+// followed by one row per game with columns week, away team, home
+// team.  Teams may use any abbreviation understood by CanonTeam.
+func ReadSchedule(scheduleCsv string) ([]*Game, error) {
+	f, err := os.Open(scheduleCsv)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	if _, err := r.Read(); err != nil { // discard header
+		return nil, err
+	}
+	var games []*Game
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		week, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, err
+		}
+		away, err := CanonTeam(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("week %d: %s", week, err)
+		}
+		home, err := CanonTeam(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("week %d: %s", week, err)
+		}
+		games = append(games, &Game{week, away, home})
+	}
+	return games, nil
+}
+
+// ByeWeeks returns the first week in which each team does not play.
+// Teams that play every week are omitted.
+func ByeWeeks(games []*Game) map[string]int {
+	maxWeek := 0
+	played := make(map[string]map[int]bool)
+	for _, g := range games {
+		for _, t := range []string{g.Away, g.Home} {
+			if played[t] == nil {
+				played[t] = make(map[int]bool)
+			}
+			played[t][g.Week] = true
+		}
+		if g.Week > maxWeek {
+			maxWeek = g.Week
+		}
+	}
+	byes := make(map[string]int)
+	for t, weeks := range played {
+		for w := 1; w <= maxWeek; w++ {
+			if !weeks[w] {
+				byes[t] = w
+				break
+			}
+		}
+	}
+	return byes
+}
@@ -0,0 +1,42 @@
+package fantasy
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestReadScheduleByeWeeks(t *testing.T) {
+	filename := path.Join(t.TempDir(), "schedule.csv")
+	content := "week,away,home\n" +
+		"1,KC,Baltimore Ravens\n" +
+		"1,NE,Cin\n" +
+		"2,Bal,NE\n" +
+		"3,kc,NE\n" +
+		"3,Bal,Cin\n"
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	games, err := ReadSchedule(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := games[0], (&Game{1, "KC", "Bal"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("first game = %+v, want %+v", got, want)
+	}
+
+	// Bal and NE play every week, so have no bye.
+	want := map[string]int{"KC": 2, "Cin": 2}
+	if got := ByeWeeks(games); !reflect.DeepEqual(got, want) {
+		t.Errorf("ByeWeeks = %v, want %v", got, want)
+	}
+
+	bad := path.Join(t.TempDir(), "bad.csv")
+	if err := os.WriteFile(bad, []byte("week,away,home\n1,KC,XYZ\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSchedule(bad); err == nil {
+		t.Errorf("ReadSchedule with an unknown team succeeded, want error")
+	}
+}
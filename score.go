@@ -1,11 +1,35 @@
 package fantasy
 
+import (
+	"sort"
+	"strings"
+)
+
+// A Scorer scores a team's lineup.  Use NewScorer to make one.
 type Scorer struct {
-	Schema []byte
-	Bench  bool
+	schema      []byte
+	bench       bool
+	flex        map[byte]string // flex letter -> allowed positions
+	flexLetters []byte          // keys of flex in ascending order
+}
+
+// NewScorer returns a Scorer for the schema.  flex maps each flex
+// letter to its allowed positions; nil means defaultFlex.
+func NewScorer(schema []byte, bench bool, flex map[byte]string) *Scorer {
+	if flex == nil {
+		flex = defaultFlex
+	}
+	var flexLetters []byte
+	for f := range flex {
+		flexLetters = append(flexLetters, f)
+	}
+	sort.Slice(flexLetters, func(i, j int) bool { return flexLetters[i] < flexLetters[j] })
+	return &Scorer{schema: schema, bench: bench, flex: flex, flexLetters: flexLetters}
 }
 
 var (
+	defaultFlex = map[byte]string{'X': "R"}
+
 	benchWeights = map[byte][]float64{
 		'D': {0.2},
 		'K': {},
@@ -16,15 +40,18 @@ var (
 	}
 )
 
+// Score returns the points of the team's starters plus weighted bench
+// points if bench is set.
 func (s *Scorer) Score(team *Team) float64 {
 	start := make(map[byte]int)
 	bench := make(map[byte]int)
-	for _, ch := range s.Schema {
+	for _, ch := range s.schema {
 		if ch != 'B' {
 			start[ch]++
 		}
 	}
 	result := 0.0
+next_player:
 	for _, player := range team.PlayersByPoints() {
 		ch := player.Pos[0]
 		if start[ch] > 0 {
@@ -32,15 +59,14 @@ func (s *Scorer) Score(team *Team) float64 {
 			result += player.Points
 			continue
 		}
-		switch ch {
-		case 'R':
-			if start['X'] > 0 {
-				start['X']--
+		for _, f := range s.flexLetters {
+			if start[f] > 0 && strings.IndexByte(s.flex[f], ch) >= 0 {
+				start[f]--
 				result += player.Points
-				continue
+				continue next_player
 			}
 		}
-		if s.Bench {
+		if s.bench {
 			if bench[ch] < len(benchWeights[ch]) {
 				result += player.Points*benchWeights[ch][bench[ch]] + 0.5
 				bench[ch]++
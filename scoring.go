@@ -0,0 +1,194 @@
+package fantasy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Tier awards Points for a value of at least Min, up to the next
+// tier's Min.  Values below the first tier's Min get the first tier's
+// Points.
+type Tier struct {
+	Min    int     `json:"min"`
+	Points float64 `json:"points"`
+}
+
+// Tiers must be sorted by Min in ascending order.
+type Tiers []Tier
+
+// Index returns the index of the tier containing v.
+func (ts Tiers) Index(v int) int {
+	i := 0
+	for i+1 < len(ts) && v >= ts[i+1].Min {
+		i++
+	}
+	return i
+}
+
+func (ts Tiers) Points(v int) float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+	return ts[ts.Index(v)].Points
+}
+
+// Label returns a human readable range for tier i, eg "7-13" or "35+".
+func (ts Tiers) Label(i int) string {
+	if i == len(ts)-1 {
+		return fmt.Sprintf("%d+", ts[i].Min)
+	}
+	if ts[i+1].Min-1 == ts[i].Min {
+		return fmt.Sprintf("%d", ts[i].Min)
+	}
+	return fmt.Sprintf("%d-%d", ts[i].Min, ts[i+1].Min-1)
+}
+
+type DSTScoring struct {
+	PointsAllowed Tiers   `json:"points_allowed"`
+	YardsAllowed  Tiers   `json:"yards_allowed"`
+	Takeaway      float64 `json:"takeaway"` // per interception or fumble recovery
+}
+
+// Bonus awards Points for each game in which Stat is at least Min,
+// eg 3 points for a 100 yard rushing game.
+type Bonus struct {
+	Stat   string  `json:"stat"`
+	Min    float64 `json:"min"`
+	Points float64 `json:"points"`
+	Pos    string  `json:"pos,omitempty"` // empty means all positions
+}
+
+// Scoring is a league's scoring settings.  Stats are keyed by the names
+// used in StatLine.
+type Scoring struct {
+	Stats    map[string]float64            `json:"stats"`    // points per unit
+	Position map[string]map[string]float64 `json:"position"` // extra points per unit by position, eg TE premium
+	Bonuses  []Bonus                       `json:"bonuses"`
+	DST      DSTScoring                    `json:"dst"`
+
+	// GameCV is the stddev of a stat in a single game as a fraction
+	// of its per game average, used to estimate how many games meet a
+	// bonus threshold.  0 means DefaultGameCV.
+	GameCV float64 `json:"game_cv,omitempty"`
+}
+
+// StatLine holds a player's projected season totals.  Stat names are:
+//
+//	g                                              games played
+//	pass_att pass_cmp pass_yds pass_td pass_int    passing
+//	rush_att rush_yds rush_td                      rushing
+//	rec rec_yds rec_td                             receiving
+//	ret_td two_pt fum_lost                         misc offense
+//	fg fga fg_0_19 fg_20_29 fg_30_39 fg_40_49 fg_50 xp    kicking
+//	sack def_int fum_rec fum_forced def_td safety blk_kick dst_ret_td
+//	pts_allowed yds_allowed                        team defense
+type StatLine map[string]float64
+
+const SeasonGames = 17
+
+// Share of made field goals by distance, used when a projection only
+// has total field goals but the scoring is by distance.
+var fgMix = map[string]float64{
+	"fg_0_19":  0.02,
+	"fg_20_29": 0.28,
+	"fg_30_39": 0.30,
+	"fg_40_49": 0.27,
+	"fg_50":    0.13,
+}
+
+const DefaultGameCV = 0.5
+
+// bonusGames returns the expected number of games in which an integer
+// stat with the given per game average is at least min, treating each
+// game's value as normal with stddev cv times the average.
+func bonusGames(games, perGame, min, cv float64) float64 {
+	if perGame <= 0 {
+		if min <= 0 {
+			return games
+		}
+		return 0
+	}
+	stddev := cv * perGame
+	return games * 0.5 * math.Erfc((min-0.5-perGame)/(stddev*math.Sqrt2))
+}
+
+// Points returns the fantasy points for a season stat line.  Bonuses
+// apply per game, so they are scored for the expected number of games
+// meeting the threshold.  DST tiers are approximated from the per game
+// averages.  Defensive interceptions and fumble recoveries score
+// DST.Takeaway unless Stats has an entry for them.  Field goals are
+// scored by total if Stats has "fg", using the line's by-distance field
+// goals only if it has no total, and otherwise by distance, splitting
+// the total only if the line has no by-distance field goals.
+func (s *Scoring) Points(pos string, line StatLine) float64 {
+	games := line["g"]
+	if games == 0 {
+		games = SeasonGames
+	}
+	cv := s.GameCV
+	if cv == 0 {
+		cv = DefaultGameCV
+	}
+	_, byTotal := s.Stats["fg"]
+	_, hasTotal := line["fg"]
+	hasDist := false
+	for dist := range fgMix {
+		if _, ok := line[dist]; ok {
+			hasDist = true
+		}
+	}
+	result := 0.0
+	for stat, v := range line {
+		if _, ok := fgMix[stat]; ok && byTotal {
+			if !hasTotal {
+				result += v * (s.Stats["fg"] + s.Position[pos]["fg"])
+			}
+			continue
+		}
+		switch stat {
+		case "g", "pts_allowed", "yds_allowed":
+			continue
+		case "def_int", "fum_rec":
+			if _, ok := s.Stats[stat]; !ok {
+				result += v * s.DST.Takeaway
+				continue
+			}
+		case "fg":
+			if !byTotal {
+				if !hasDist {
+					for dist, share := range fgMix {
+						result += v * share * (s.Stats[dist] + s.Position[pos][dist])
+					}
+				}
+				continue
+			}
+		}
+		result += v * (s.Stats[stat] + s.Position[pos][stat])
+	}
+	for _, b := range s.Bonuses {
+		if b.Pos == "" || b.Pos == pos {
+			result += bonusGames(games, line[b.Stat]/games, b.Min, cv) * b.Points
+		}
+	}
+	if pa, ok := line["pts_allowed"]; ok {
+		result += games * s.DST.PointsAllowed.Points(int(math.Round(pa/games)))
+	}
+	if ya, ok := line["yds_allowed"]; ok {
+		result += games * s.DST.YardsAllowed.Points(int(math.Round(ya/games)))
+	}
+	return result
+}
+
+func ReadScoring(filename string) (*Scoring, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var s Scoring
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return &s, nil
+}
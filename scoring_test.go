@@ -0,0 +1,79 @@
+package fantasy
+
+import (
+	"math"
+	"testing"
+)
+
+// normalAbove returns P(X > x) for X normal with the given mean and
+// stddev.
+func normalAbove(x, mean, stddev float64) float64 {
+	return 0.5 * math.Erfc((x-mean)/(stddev*math.Sqrt2))
+}
+
+func TestScoringPoints(t *testing.T) {
+	ppr := &Scoring{
+		Stats: map[string]float64{
+			"pass_yds": 0.04,
+			"pass_td":  4,
+			"pass_int": -1,
+			"rush_yds": 0.1,
+			"rec":      1,
+			"rec_yds":  0.1,
+			"fg_0_19":  3,
+			"fg_20_29": 3,
+			"fg_30_39": 3,
+			"fg_40_49": 4,
+			"fg_50":    5,
+			"xp":       1,
+		},
+		Position: map[string]map[string]float64{
+			"TE": {"rec": 0.5},
+		},
+		Bonuses: []Bonus{
+			{Stat: "rush_yds", Min: 100, Points: 3},
+		},
+		DST: DSTScoring{
+			PointsAllowed: Tiers{{0, 10}, {1, 7}, {7, 4}, {14, 1}, {21, 0}},
+			Takeaway:      2,
+		},
+	}
+	halfPPR := &Scoring{
+		Stats: map[string]float64{"rec": 0.5, "rec_yds": 0.1},
+	}
+	perFG := &Scoring{
+		Stats:   map[string]float64{"fg": 3, "def_int": 3},
+		Bonuses: []Bonus{{Stat: "rush_yds", Min: 100, Points: 3}},
+		DST:     DSTScoring{Takeaway: 2},
+		GameCV:  0.2,
+	}
+	tests := []struct {
+		name    string
+		scoring *Scoring
+		pos     string
+		line    StatLine
+		want    float64
+	}{
+		{"qb", ppr, "QB", StatLine{"pass_yds": 4000, "pass_td": 30, "pass_int": 10}, 160 + 120 - 10},
+		{"ppr wr", ppr, "WR", StatLine{"rec": 100, "rec_yds": 1200}, 100 + 120},
+		{"ppr te premium", ppr, "TE", StatLine{"rec": 100, "rec_yds": 1200}, 150 + 120},
+		{"half ppr wr", halfPPR, "WR", StatLine{"rec": 100, "rec_yds": 1200}, 50 + 120},
+		{"bonus above threshold", ppr, "RB", StatLine{"g": 10, "rush_yds": 1100}, 110 + 30*normalAbove(99.5, 110, 55)},
+		{"bonus below threshold", ppr, "RB", StatLine{"g": 10, "rush_yds": 900}, 90 + 30*normalAbove(99.5, 90, 45)},
+		{"no bonus without yards", ppr, "RB", StatLine{"g": 10}, 0},
+		{"kicker fg mix", ppr, "K", StatLine{"fg": 10, "xp": 20}, 10*(0.02*3+0.28*3+0.30*3+0.27*4+0.13*5) + 20},
+		{"kicker fg by distance", ppr, "K", StatLine{"fg": 10, "fg_40_49": 6, "fg_50": 4}, 6*4 + 4*5},
+		{"kicker fg by distance only", ppr, "K", StatLine{"fg_40_49": 6, "fg_50": 4}, 6*4 + 4*5},
+		{"kicker fg total", perFG, "K", StatLine{"fg": 10, "fg_40_49": 6, "fg_50": 4}, 30},
+		{"kicker fg total from distances", perFG, "K", StatLine{"fg_40_49": 6, "fg_50": 4}, 30},
+		{"dst", ppr, "DST", StatLine{"g": 10, "def_int": 10, "fum_rec": 5, "pts_allowed": 180}, 30 + 10*1},
+		{"dst per stat takeaway", perFG, "DST", StatLine{"def_int": 10, "fum_rec": 5}, 30 + 10},
+		{"game cv", perFG, "RB", StatLine{"g": 10, "rush_yds": 1100}, 30 * normalAbove(99.5, 110, 22)},
+	}
+	for _, tt := range tests {
+		got := tt.scoring.Points(tt.pos, tt.line)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: Points(%s, %v) = %v; want %v", tt.name, tt.pos, tt.line, got, tt.want)
+		}
+	}
+}
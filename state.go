@@ -18,6 +18,16 @@ func ReadState(playersCsv string, numTeams int, order []int) (*State, []int, err
 	if err != nil {
 		return nil, nil, err
 	}
+	state, newOrder := NewState(players, numTeams, order)
+	return state, newOrder, nil
+}
+
+// NewState returns the state before the first pick that doesn't have a
+// player yet.  Players with a nonzero Pick are keepers.  It also returns
+// a copy of the order with -1 for keeper picks.  The players are not
+// modified.
+func NewState(players []*Player, numTeams int, order []int) (*State, []int) {
+	players = clonePlayers(players)
 
 	drafted := make(map[int]*Player)
 	var undraftedByPoints []*Player
@@ -75,7 +85,7 @@ func ReadState(playersCsv string, numTeams int, order []int) (*State, []int, err
 		Pick:              pick,
 		Drafted:           draftedBool,
 		Players:           playerMap,
-	}, newOrder, nil
+	}, newOrder
 }
 
 func (st *State) Clone() *State {
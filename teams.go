@@ -0,0 +1,102 @@
+package fantasy
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// Mapping of NFL team formats from raw -> canon.
+	teams = map[string]string{
+		"ARI": "Ari",
+		"ATL": "Atl",
+		"BAL": "Bal",
+		"BUF": "Buf",
+		"CAR": "Car",
+		"CHI": "Chi",
+		"CIN": "Cin",
+		"CLE": "Cle",
+		"DAL": "Dal",
+		"DEN": "Den",
+		"DET": "Det",
+		"GB":  "GB",
+		"HOU": "Hou",
+		"IND": "Ind",
+		"JAC": "Jax",
+		"JAX": "Jax",
+		"KC":  "KC",
+		"LAC": "LAC",
+		"LAR": "LAR",
+		"LV":  "LV",
+		"MIA": "Mia",
+		"MIN": "Min",
+		"NE":  "NE",
+		"NO":  "NO",
+		"NYG": "NYG",
+		"NYJ": "NYJ",
+		"PHI": "Phi",
+		"PIT": "Pit",
+		"SEA": "Sea",
+		"SF":  "SF",
+		"TB":  "TB",
+		"TEN": "Ten",
+		"WAS": "Was",
+		"FA":  "FA",
+		"":    "",
+	}
+
+	// Mapping of full NFL team names, past and present -> canon.
+	teamNames = map[string]string{
+		"Arizona Cardinals":        "Ari",
+		"Atlanta Falcons":          "Atl",
+		"Baltimore Ravens":         "Bal",
+		"Buffalo Bills":            "Buf",
+		"Carolina Panthers":        "Car",
+		"Chicago Bears":            "Chi",
+		"Cincinnati Bengals":       "Cin",
+		"Cleveland Browns":         "Cle",
+		"Dallas Cowboys":           "Dal",
+		"Denver Broncos":           "Den",
+		"Detroit Lions":            "Det",
+		"Green Bay Packers":        "GB",
+		"Houston Texans":           "Hou",
+		"Indianapolis Colts":       "Ind",
+		"Jacksonville Jaguars":     "Jax",
+		"Kansas City Chiefs":       "KC",
+		"Los Angeles Chargers":     "LAC",
+		"San Diego Chargers":       "LAC",
+		"Los Angeles Rams":         "LAR",
+		"St. Louis Rams":           "LAR",
+		"Las Vegas Raiders":        "LV",
+		"Oakland Raiders":          "LV",
+		"Miami Dolphins":           "Mia",
+		"Minnesota Vikings":        "Min",
+		"New England Patriots":     "NE",
+		"New Orleans Saints":       "NO",
+		"New York Giants":          "NYG",
+		"New York Jets":            "NYJ",
+		"Philadelphia Eagles":      "Phi",
+		"Pittsburgh Steelers":      "Pit",
+		"Seattle Seahawks":         "Sea",
+		"San Francisco 49ers":      "SF",
+		"Tampa Bay Buccaneers":     "TB",
+		"Tennessee Titans":         "Ten",
+		"Washington Commanders":    "Was",
+		"Washington Football Team": "Was",
+		"Washington Redskins":      "Was",
+	}
+)
+
+// CanonTeam returns the canonical abbreviation for an NFL team, eg
+// "JAC" -> "Jax" or "Jacksonville Jaguars" -> "Jax".  Lookup of
+// abbreviations is case-insensitive.
+func CanonTeam(raw string) (string, error) {
+	canon, ok := teams[strings.ToUpper(raw)]
+	if !ok {
+		canon, ok = teamNames[raw]
+	}
+	if !ok {
+		return "", fmt.Errorf("unknown team: %q", raw)
+	}
+	return canon, nil
+}
@@ -0,0 +1,29 @@
+package fantasy
+
+import "testing"
+
+func TestCanonTeam(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"KC", "KC", false},
+		{"kc", "KC", false},
+		{"JAC", "Jax", false},
+		{"Jax", "Jax", false},
+		{"Jacksonville Jaguars", "Jax", false},
+		{"Oakland Raiders", "LV", false},
+		{"Washington Football Team", "Was", false},
+		{"FA", "FA", false},
+		{"", "", false},
+		{"XYZ", "", true},
+		{"jacksonville jaguars", "", true},
+	}
+	for _, tt := range tests {
+		got, err := CanonTeam(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("CanonTeam(%q) = %q, %v; want %q, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -2,10 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"os"
+	"os/exec"
 	"path"
+	"runtime"
+	"time"
 
 	"github.com/dbtleonia/fantasy/yahoo"
 	"golang.org/x/oauth2"
@@ -16,34 +23,100 @@ const (
 	tokenFile   = "token.json"
 )
 
+var (
+	redirectURL = flag.String("redirect_url", "", "redirect URL registered for the app, eg http://localhost:8080/callback, or oob to paste the code; empty means the one in client_secrets.json, else oob")
+	browser     = flag.Bool("browser", true, "open the auth dialog in a browser")
+	timeout     = flag.Duration("timeout", 5*time.Minute, "how long to wait for the redirect to a local listener")
+)
+
+// openBrowser tries to open u in the user's browser; if it can't, the
+// user can still visit the printed URL.
+func openBrowser(u string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	if err := cmd.Start(); err != nil {
+		log.Printf("open browser: %s", err)
+	}
+}
+
+func visit(authURL string) {
+	fmt.Printf("Visit the URL for the auth dialog: %v\n", authURL)
+	fmt.Printf("\n")
+	if *browser {
+		openBrowser(authURL)
+	}
+}
+
+// oobToken has the user paste the code Yahoo shows them.
+func oobToken(ctx context.Context, conf *oauth2.Config) (*oauth2.Token, error) {
+	verifier := oauth2.GenerateVerifier()
+	visit(conf.AuthCodeURL("state", oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)))
+	fmt.Printf("Enter code: ")
+	var code string
+	if _, err := fmt.Scan(&code); err != nil {
+		return nil, err
+	}
+	return conf.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+}
+
+// loopbackToken receives the code on a local listener at the redirect
+// URL, on port 80 if the URL has none, giving up after -timeout.
+func loopbackToken(ctx context.Context, conf *oauth2.Config) (*oauth2.Token, error) {
+	u, err := url.Parse(conf.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("redirect URL %s: the local listener only serves http", conf.RedirectURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	defer ln.Close()
+	fmt.Printf("Waiting for the redirect to %s\n", conf.RedirectURL)
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	tok, err := yahoo.LoopbackToken(ctx, conf, ln, visit)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("no redirect after %s; try again, or use -redirect_url=oob to paste the code", *timeout)
+	}
+	return tok, err
+}
+
 func main() {
+	flag.Parse()
 	home, _ := os.UserHomeDir()
 
-	//
-	// Mostly copied from https://pkg.go.dev/golang.org/x/oauth2#example-Config
-	//
 	ctx := context.Background()
 	conf, err := yahoo.ReadConfig(path.Join(home, secretsFile))
 	if err != nil {
 		log.Fatal(err)
 	}
+	if *redirectURL != "" {
+		conf.RedirectURL = *redirectURL
+	}
+	if conf.RedirectURL == "" {
+		conf.RedirectURL = "oob"
+	}
 
-	// Redirect user to consent page to ask for permission
-	// for the scopes specified above.
-	url := conf.AuthCodeURL("state", oauth2.AccessTypeOffline)
-	fmt.Printf("Visit the URL for the auth dialog: %v\n", url)
-	fmt.Printf("\n")
-	fmt.Printf("Enter code: ")
-
-	// Use the authorization code that is pushed to the redirect
-	// URL. Exchange will do the handshake to retrieve the
-	// initial access token. The HTTP Client returned by
-	// conf.Client will refresh the token as necessary.
-	var code string
-	if _, err := fmt.Scan(&code); err != nil {
-		log.Fatal(err)
+	var tok *oauth2.Token
+	if conf.RedirectURL == "oob" {
+		tok, err = oobToken(ctx, conf)
+	} else {
+		tok, err = loopbackToken(ctx, conf)
 	}
-	tok, err := conf.Exchange(ctx, code)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,360 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultBaseURL = "https://fantasysports.yahooapis.com/fantasy/v2"
+
+// Client makes requests to the Yahoo Fantasy API.  HTTP should add the
+// OAuth token, eg a client from oauth2.NewClient.
+type Client struct {
+	HTTP    *http.Client
+	BaseURL string
+
+	// Requests that are rate limited or fail with a server error are
+	// retried up to MaxRetries times, waiting Backoff before the first
+	// retry and doubling the wait each time after, unless the response
+	// says how long to wait.
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+func NewClient(hc *http.Client) *Client {
+	return &Client{
+		HTTP:       hc,
+		BaseURL:    DefaultBaseURL,
+		MaxRetries: 4,
+		Backoff:    2 * time.Second,
+	}
+}
+
+// StatusError is returned for responses whose status isn't 2xx.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Body       string // start of the body, which usually says what's wrong
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s: %s", e.URL, e.Status)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.URL, e.Status, e.Body)
+}
+
+// Temporary reports whether the request may succeed if retried.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// URL returns the full URL for a resource path like /league/<key>.
+// Full URLs are returned unchanged.
+func (c *Client) URL(uriPath string) string {
+	if strings.HasPrefix(uriPath, "https://") || strings.HasPrefix(uriPath, "http://") {
+		return uriPath
+	}
+	return fmt.Sprintf("%s%s?format=json_f", c.BaseURL, uriPath)
+}
+
+// Fetch returns the raw json_f response for a resource path, retrying
+// temporary failures.
+func (c *Client) Fetch(ctx context.Context, uriPath string) ([]byte, error) {
+	wait := c.Backoff
+	for attempt := 0; ; attempt++ {
+		b, retryAfter, err := c.fetch(ctx, uriPath)
+		if err == nil {
+			return b, nil
+		}
+		if se, ok := err.(*StatusError); !ok || !se.Temporary() || attempt >= c.MaxRetries {
+			return nil, err
+		}
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		log.Printf("%s; retrying in %s", err, wait)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// fetch makes one request.  For failed requests it also returns how
+// long the response asked us to wait, if it did.
+func (c *Client) fetch(ctx context.Context, uriPath string) ([]byte, time.Duration, error) {
+	u := c.URL(uriPath)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body := strings.TrimSpace(string(b))
+		if len(body) > 200 {
+			body = body[:200] + "..."
+		}
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, retryAfter, &StatusError{u, resp.StatusCode, resp.Status, body}
+	}
+	return b, 0, nil
+}
+
+// Get returns the decoded response for a resource path.
+func (c *Client) Get(ctx context.Context, uriPath string) (*Response, error) {
+	b, err := c.Fetch(ctx, uriPath)
+	if err != nil {
+		return nil, err
+	}
+	var r Response
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("%s: %s", uriPath, err)
+	}
+	return &r, nil
+}
+
+func (c *Client) league(ctx context.Context, uriPath string) (*League, error) {
+	r, err := c.Get(ctx, uriPath)
+	if err != nil {
+		return nil, err
+	}
+	if r.FantasyContent.League == nil {
+		return nil, fmt.Errorf("%s: no league", uriPath)
+	}
+	return r.FantasyContent.League, nil
+}
+
+func (c *Client) team(ctx context.Context, uriPath string) (*Team, error) {
+	r, err := c.Get(ctx, uriPath)
+	if err != nil {
+		return nil, err
+	}
+	if r.FantasyContent.Team == nil {
+		return nil, fmt.Errorf("%s: no team", uriPath)
+	}
+	return r.FantasyContent.Team, nil
+}
+
+func teams(ts []struct {
+	Team Team `json:"team"`
+}) []*Team {
+	result := make([]*Team, len(ts))
+	for i := range ts {
+		result[i] = &ts[i].Team
+	}
+	return result
+}
+
+func players(ps []struct {
+	Player Player `json:"player"`
+}) []*Player {
+	result := make([]*Player, len(ps))
+	for i := range ps {
+		result[i] = &ps[i].Player
+	}
+	return result
+}
+
+// League returns the league metadata.
+func (c *Client) League(ctx context.Context, leagueKey string) (*League, error) {
+	return c.league(ctx, "/league/"+leagueKey)
+}
+
+// Settings returns the league with its settings.
+func (c *Client) Settings(ctx context.Context, leagueKey string) (*League, error) {
+	l, err := c.league(ctx, "/league/"+leagueKey+"/settings")
+	if err != nil {
+		return nil, err
+	}
+	if l.Settings == nil {
+		return nil, fmt.Errorf("league %s: no settings", leagueKey)
+	}
+	return l, nil
+}
+
+// Standings returns the teams in standings order with their records and
+// season points.
+func (c *Client) Standings(ctx context.Context, leagueKey string) ([]*Team, error) {
+	l, err := c.league(ctx, "/league/"+leagueKey+"/standings")
+	if err != nil {
+		return nil, err
+	}
+	if l.Standings == nil {
+		return nil, fmt.Errorf("league %s: no standings", leagueKey)
+	}
+	return teams(l.Standings.Teams), nil
+}
+
+// Scoreboard returns the matchups for a week; week 0 means the current
+// week.
+func (c *Client) Scoreboard(ctx context.Context, leagueKey string, week int) (*Scoreboard, error) {
+	u := "/league/" + leagueKey + "/scoreboard"
+	if week > 0 {
+		u += fmt.Sprintf(";week=%d", week)
+	}
+	l, err := c.league(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if l.Scoreboard == nil {
+		return nil, fmt.Errorf("league %s: no scoreboard", leagueKey)
+	}
+	return l.Scoreboard, nil
+}
+
+// Teams returns the teams with their managers.
+func (c *Client) Teams(ctx context.Context, leagueKey string) ([]*Team, error) {
+	l, err := c.league(ctx, "/league/"+leagueKey+"/teams")
+	if err != nil {
+		return nil, err
+	}
+	return teams(l.Teams), nil
+}
+
+// Matchups returns a team's matchups for the season.
+func (c *Client) Matchups(ctx context.Context, teamKey string) ([]*Matchup, error) {
+	t, err := c.team(ctx, "/team/"+teamKey+"/matchups")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Matchup, len(t.Matchups))
+	for i := range t.Matchups {
+		result[i] = &t.Matchups[i].Matchup
+	}
+	return result, nil
+}
+
+// Roster returns a team with its roster for a week, with each player's
+// stats and points; week 0 means the current week.
+func (c *Client) Roster(ctx context.Context, teamKey string, week int) (*Team, error) {
+	u := "/team/" + teamKey + "/roster"
+	if week > 0 {
+		u += fmt.Sprintf(";week=%d", week)
+	}
+	t, err := c.team(ctx, u+"/players/stats")
+	if err != nil {
+		return nil, err
+	}
+	if t.Roster == nil {
+		return nil, fmt.Errorf("team %s: no roster", teamKey)
+	}
+	return t, nil
+}
+
+// PlayersQuery filters a players request.  Zero values are left out.
+type PlayersQuery struct {
+	Status   string // eg A for available, T for taken
+	Position string
+	Start    int
+	Count    int // Yahoo returns at most 25
+}
+
+func (q *PlayersQuery) params() string {
+	var s string
+	if q.Status != "" {
+		s += ";status=" + url.PathEscape(q.Status)
+	}
+	if q.Position != "" {
+		s += ";position=" + url.PathEscape(q.Position)
+	}
+	if q.Start > 0 {
+		s += fmt.Sprintf(";start=%d", q.Start)
+	}
+	if q.Count > 0 {
+		s += fmt.Sprintf(";count=%d", q.Count)
+	}
+	return s
+}
+
+// PlayersPath returns the resource path for a page of the league's
+// players with their season stats and points.
+func PlayersPath(leagueKey string, q *PlayersQuery) string {
+	return "/league/" + leagueKey + "/players" + q.params() + "/stats"
+}
+
+// Players returns one page of the league's players with their season
+// stats and points.
+func (c *Client) Players(ctx context.Context, leagueKey string, q *PlayersQuery) ([]*Player, error) {
+	l, err := c.league(ctx, PlayersPath(leagueKey, q))
+	if err != nil {
+		return nil, err
+	}
+	return players(l.Players), nil
+}
+
+// PageSize is the most players Yahoo returns per request.
+const PageSize = 25
+
+// AllPlayers returns the players matching the query, requesting pages
+// from q.Start until a short page says they're exhausted.  q.Count is
+// ignored.
+func (c *Client) AllPlayers(ctx context.Context, leagueKey string, q *PlayersQuery) ([]*Player, error) {
+	page := *q
+	page.Count = PageSize
+	var result []*Player
+	for {
+		ps, err := c.Players(ctx, leagueKey, &page)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ps...)
+		if len(ps) < PageSize {
+			return result, nil
+		}
+		page.Start += PageSize
+	}
+}
+
+// DraftResults returns the picks in order, with the players drafted.
+func (c *Client) DraftResults(ctx context.Context, leagueKey string) ([]*DraftResult, error) {
+	l, err := c.league(ctx, "/league/"+leagueKey+"/draftresults/players")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*DraftResult, len(l.DraftResults))
+	for i := range l.DraftResults {
+		result[i] = &l.DraftResults[i].DraftResult
+	}
+	return result, nil
+}
+
+// Transactions returns the league's transactions, most recent first.
+// If types is not empty, only those types are returned, eg "add,drop".
+func (c *Client) Transactions(ctx context.Context, leagueKey, types string) ([]*Transaction, error) {
+	u := "/league/" + leagueKey + "/transactions"
+	if types != "" {
+		u += ";types=" + types
+	}
+	l, err := c.league(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Transaction, len(l.Transactions))
+	for i := range l.Transactions {
+		result[i] = &l.Transactions[i].Transaction
+	}
+	return result, nil
+}
@@ -0,0 +1,273 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dbtleonia/fantasy/yahoo/yahootest"
+)
+
+func testClient(t *testing.T) *Client {
+	srv := yahootest.NewServer("testdata")
+	t.Cleanup(srv.Close)
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL + yahootest.APIPath
+	return c
+}
+
+func TestDecode(t *testing.T) {
+	var v struct {
+		N []Number
+		I []Int
+		B []Bool
+	}
+	in := `{"N": [1.5, "2.25", ""], "I": [3, "4", "5.0"], "B": [true, 0, "1", "", "false", null]}`
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := []Number{1.5, 2.25, 0}; !reflect.DeepEqual(v.N, want) {
+		t.Errorf("Number: got %v, want %v", v.N, want)
+	}
+	if want := []Int{3, 4, 5}; !reflect.DeepEqual(v.I, want) {
+		t.Errorf("Int: got %v, want %v", v.I, want)
+	}
+	if want := []Bool{true, false, true, false, false, false}; !reflect.DeepEqual(v.B, want) {
+		t.Errorf("Bool: got %v, want %v", v.B, want)
+	}
+}
+
+func TestClientLeague(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	l, err := c.League(ctx, "449.l.12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "Gridiron Club" || l.NumTeams != 4 || l.Season != 2024 {
+		t.Errorf("League: got %q, %d teams, season %d", l.Name, l.NumTeams, l.Season)
+	}
+
+	l, err = c.Settings(ctx, "449.l.12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := l.Settings
+	if s.MaxKeepers != 2 || len(s.RosterPositions) != 3 || s.RosterPositions[1].RosterPosition.Count != 1 {
+		t.Errorf("Settings: got %d keepers, roster positions %+v", s.MaxKeepers, s.RosterPositions)
+	}
+	if m := s.StatModifiers.Stats; len(m) != 2 || m[0].Stat.Value != 0.04 || m[1].Stat.Bonuses[0].Bonus.Points != 1.5 {
+		t.Errorf("Settings: got stat modifiers %+v", m)
+	}
+	if d := s.Divisions; len(d) != 2 || d[1].Division.DivisionID != 2 || d[1].Division.Name != "West" {
+		t.Errorf("Settings: got divisions %+v", d)
+	}
+}
+
+func TestClientTeams(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	standings, err := c.Standings(ctx, "449.l.12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, team := range standings {
+		ts := team.TeamStandings
+		got = append(got, fmt.Sprintf("%d %s div %d %.2f %d-%d-%d %.1f",
+			ts.Rank, team.Name, team.DivisionID, team.TeamPoints.Total,
+			ts.OutcomeTotals.Wins, ts.OutcomeTotals.Losses, ts.OutcomeTotals.Ties, ts.PointsAgainst))
+	}
+	want := []string{
+		"1 Bob's Bombers div 1 412.36 3-0-0 301.5",
+		"2 Al's Aces div 2 355.00 1-1-1 360.1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Standings: got %q, want %q", got, want)
+	}
+
+	teams, err := c.Teams(ctx, "449.l.12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 2 || len(teams[1].Managers) != 2 || teams[1].Managers[1].Manager.GUID != "BBBB3333" {
+		t.Errorf("Teams: got %+v", teams)
+	}
+
+	sb, err := c.Scoreboard(ctx, "449.l.12345", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := sb.Matchups[0].Matchup
+	if sb.Week != 3 || m.Status != "postevent" || len(m.Teams) != 2 ||
+		m.Teams[0].Team.TeamPoints.Total != 101.2 || m.Teams[1].Team.TeamProjectedPoints.Total != 104.1 {
+		t.Errorf("Scoreboard: got %+v", sb)
+	}
+
+	matchups, err := c.Matchups(ctx, "449.l.12345.t.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matchups) != 2 || matchups[1].Week != 2 || matchups[1].Teams[1].Team.Name != "Bob's Bombers" {
+		t.Errorf("Matchups: got %+v", matchups)
+	}
+}
+
+func TestClientPlayers(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	team, err := c.Roster(ctx, "449.l.12345.t.1", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range team.Roster.Players {
+		pl := p.Player
+		got = append(got, fmt.Sprintf("%d %s %s %.2f", pl.PlayerID, pl.Name.Full, pl.SelectedPosition.Position, pl.PlayerPoints.Total))
+	}
+	want := []string{"30123 Patrick Mahomes QB 21.24", "32692 Travis Etienne BN 0.00"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Roster: got %q, want %q", got, want)
+	}
+	if stats := team.Roster.Players[0].Player.PlayerStats.Stats; len(stats) != 3 || stats[0].Stat.Value != 331 || stats[2].Stat.Value != 0 {
+		t.Errorf("Roster: got stats %+v", stats)
+	}
+
+	players, err := c.Players(ctx, "449.l.12345", &PlayersQuery{Status: "A", Start: 25, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != 2 || players[0].PrimaryPosition != "WR" || players[1].PlayerPoints.Total != 27 {
+		t.Errorf("Players: got %+v", players)
+	}
+
+	picks, err := c.DraftResults(ctx, "449.l.12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, d := range picks {
+		kept := "-"
+		if d.Player != nil {
+			kept = fmt.Sprint(bool(d.Player.IsKeeper.Kept))
+		}
+		got = append(got, fmt.Sprintf("%d.%d %s %s", d.Round, d.Pick, d.PlayerKey, kept))
+	}
+	want = []string{"1.1 449.p.30123 true", "1.2 449.p.32692 false", "1.3  -"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DraftResults: got %q, want %q", got, want)
+	}
+
+	txs, err := c.Transactions(ctx, "449.l.12345", "add,drop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, tx := range txs {
+		for _, p := range tx.Players {
+			got = append(got, fmt.Sprintf("%d %s %d %s", tx.Timestamp, tx.Type, p.Player.PlayerID, p.Player.TransactionData.Type))
+		}
+	}
+	want = []string{
+		"1727300000 add/drop 33001 add",
+		"1727300000 add/drop 32692 drop",
+		"1727200000 drop 100008 drop",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Transactions: got %q, want %q", got, want)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	c := testClient(t)
+	if _, err := c.League(context.Background(), "no.such.league"); err == nil {
+		t.Error("League: got no error for 404")
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		desc     string
+		statuses []int // until the fixture is served
+		requests int
+		wantErr  int
+	}{
+		{"ok", nil, 1, 0},
+		{"rate limited", []int{429, 429}, 3, 0},
+		{"server error", []int{503}, 2, 0},
+		{"unauthorized", []int{401}, 1, 401},
+		{"too many retries", []int{500, 500, 500}, 3, 500},
+	}
+	for _, test := range tests {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests <= len(test.statuses) {
+				http.Error(w, "nope", test.statuses[requests-1])
+				return
+			}
+			http.ServeFile(w, r, "testdata/league_449.l.12345.json")
+		}))
+		c := NewClient(srv.Client())
+		c.BaseURL = srv.URL
+		c.MaxRetries = 2
+		c.Backoff = time.Millisecond
+		_, err := c.League(context.Background(), "449.l.12345")
+		srv.Close()
+
+		gotErr := 0
+		if se, ok := err.(*StatusError); ok {
+			gotErr = se.StatusCode
+		} else if err != nil {
+			t.Errorf("%s: got %v", test.desc, err)
+		}
+		if gotErr != test.wantErr || requests != test.requests {
+			t.Errorf("%s: got status %d after %d requests, want %d after %d", test.desc, gotErr, requests, test.wantErr, test.requests)
+		}
+	}
+}
+
+func TestAllPlayers(t *testing.T) {
+	const numPlayers = 60
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, count int
+		for _, param := range strings.Split(r.URL.Path, ";") {
+			fmt.Sscanf(param, "start=%d", &start)
+			fmt.Sscanf(param, "count=%d", &count)
+		}
+		starts = append(starts, fmt.Sprint(start))
+		var l League
+		for id := start; id < start+count && id < numPlayers; id++ {
+			var p struct {
+				Player Player `json:"player"`
+			}
+			p.Player.PlayerID = Int(id)
+			l.Players = append(l.Players, p)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"fantasy_content": map[string]interface{}{"league": l}})
+	}))
+	defer srv.Close()
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	players, err := c.AllPlayers(context.Background(), "449.l.12345", &PlayersQuery{Status: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(players) != numPlayers || players[numPlayers-1].PlayerID != numPlayers-1 {
+		t.Errorf("got %d players", len(players))
+	}
+	if want := []string{"0", "25", "50"}; !reflect.DeepEqual(starts, want) {
+		t.Errorf("got starts %q, want %q", starts, want)
+	}
+}
@@ -4,38 +4,56 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
 	"path"
 	"strconv"
-	"strings"
 
+	"github.com/dbtleonia/fantasy"
 	"github.com/dbtleonia/fantasy/yahoo"
+	"github.com/dbtleonia/fantasy/yahoo/yahootest"
 	"golang.org/x/oauth2"
 )
 
-func leaguePlayersAvailable(client *http.Client, league, suffix string) error {
-	// TODO: Don't hardcode limit.
-	for start := 0; start < 950; start += 25 {
-		u := fmt.Sprintf("/league/%s/players;status=A;start=%d;count=25/stats", league, start)
-		f := fmt.Sprintf("league_players_available_%s_%04d.json", suffix, start)
-		if err := getToFile(client, u, f); err != nil {
+func leaguePlayersAvailable(client *yahoo.Client, league, suffix string) error {
+	q := &yahoo.PlayersQuery{Status: "A", Count: yahoo.PageSize}
+	for {
+		b, err := client.Fetch(context.Background(), yahoo.PlayersPath(league, q))
+		if err != nil {
+			return err
+		}
+		var r yahoo.Response
+		if err := json.Unmarshal(b, &r); err != nil {
+			return fmt.Errorf("players at %d: %s", q.Start, err)
+		}
+		if r.FantasyContent.League == nil {
+			return fmt.Errorf("players at %d: no league", q.Start)
+		}
+		n := len(r.FantasyContent.League.Players)
+		if n == 0 {
+			return nil
+		}
+		if err := writeFile(fmt.Sprintf("league_players_available_%s_%04d.json", suffix, q.Start), b); err != nil {
 			return err
 		}
+		if n < yahoo.PageSize {
+			return nil
+		}
+		q.Start += yahoo.PageSize
 	}
-	return nil
 }
 
-func leagueScoreboard(client *http.Client, league string, week int) error {
+func leagueScoreboard(client *yahoo.Client, league string, week int) error {
 	u := fmt.Sprintf("/league/%s/scoreboard;week=%d", league, week)
 	f := fmt.Sprintf("league_scoreboard_week%02d.json", week)
 	return getToFile(client, u, f)
 }
 
-func leagueStandings(client *http.Client, league, suffix string) error {
+func leagueStandings(client *yahoo.Client, league, suffix string) error {
 	u := fmt.Sprintf("/league/%s/standings", league)
 	f := fmt.Sprintf("league_standings_%s.json", suffix)
 	if err := getToFile(client, u, f); err != nil {
@@ -47,10 +65,14 @@ func leagueStandings(client *http.Client, league, suffix string) error {
 	return getToFile(client, u2, f2)
 }
 
-func teamMatchups(client *http.Client, league string) error {
-	for t := 1; t <= 12; t++ {
-		u := fmt.Sprintf("/team/%s.t.%d/matchups", league, t)
-		f := fmt.Sprintf("team_matchups_%02d.json", t)
+func teamMatchups(client *yahoo.Client, league string) error {
+	teams, err := client.Teams(context.Background(), league)
+	if err != nil {
+		return err
+	}
+	for _, t := range teams {
+		u := fmt.Sprintf("/team/%s/matchups", t.TeamKey)
+		f := fmt.Sprintf("team_matchups_%02d.json", t.TeamID)
 		if err := getToFile(client, u, f); err != nil {
 			return err
 		}
@@ -58,10 +80,14 @@ func teamMatchups(client *http.Client, league string) error {
 	return nil
 }
 
-func teamRosters(client *http.Client, league string, week int) error {
-	for t := 1; t <= 12; t++ {
-		u := fmt.Sprintf("/team/%s.t.%d/roster;week=%d/players/stats", league, t, week)
-		f := fmt.Sprintf("team_rosters_week%02d_%02d.json", week, t)
+func teamRosters(client *yahoo.Client, league string, week int) error {
+	teams, err := client.Teams(context.Background(), league)
+	if err != nil {
+		return err
+	}
+	for _, t := range teams {
+		u := fmt.Sprintf("/team/%s/roster;week=%d/players/stats", t.TeamKey, week)
+		f := fmt.Sprintf("team_rosters_week%02d_%02d.json", week, t.TeamID)
 		if err := getToFile(client, u, f); err != nil {
 			return err
 		}
@@ -69,49 +95,90 @@ func teamRosters(client *http.Client, league string, week int) error {
 	return nil
 }
 
-func getToFile(client *http.Client, uriPath, file string) error {
-	resp, err := client.Get(fullURI(uriPath))
+func getToFile(client *yahoo.Client, uriPath, file string) error {
+	b, err := client.Fetch(context.Background(), uriPath)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	return writeFile(file, b)
+}
 
-	home, _ := os.UserHomeDir()
-	filename := path.Join(home, file)
-	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+// writeFile writes to a temporary file and renames it into place, so a
+// failure never leaves a partial file behind.
+func writeFile(file string, b []byte) error {
+	filename := path.Join(*outDir, file)
+	f, err := os.CreateTemp(*outDir, "."+file+".tmp*")
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(f, resp.Body)
-	if _, err0 := f.Write([]byte("\n")); err0 != nil && err == nil {
-		err = err0
-	}
+	_, err = f.Write(append(b, '\n'))
 	if err1 := f.Close(); err1 != nil && err == nil {
 		err = err1
 	}
 	if err == nil {
-		log.Printf("Wrote %s\n", filename)
+		err = os.Rename(f.Name(), filename)
 	}
-	return err
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	log.Printf("Wrote %s\n", filename)
+	return nil
 }
 
-func getToStdout(client *http.Client, uri string) error {
-	if !strings.HasPrefix(uri, "https://") {
-		uri = fullURI(uri)
+// weekArg returns the week given as the command's argument.
+func weekArg(args []string) int {
+	if len(args) != 2 {
+		log.Fatal("gimme week")
+	}
+	week, err := strconv.Atoi(args[1])
+	if err != nil {
+		log.Fatal(err)
 	}
-	resp, err := client.Get(uri)
+	return week
+}
+
+func getToStdout(client *yahoo.Client, uri string) error {
+	b, err := client.Fetch(context.Background(), uri)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	_, err = io.Copy(os.Stdout, resp.Body)
+	_, err = os.Stdout.Write(b)
 	return err
 }
 
-func fullURI(uriPath string) string {
-	return fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2%s?format=json_f", uriPath)
+// run downloads what the command in args asks for.
+func run(client *yahoo.Client, league string, args []string) error {
+	switch args[0] {
+	case "a":
+		if len(args) != 2 {
+			log.Fatal("gimme suffix")
+		}
+		return leaguePlayersAvailable(client, league, args[1])
+	case "b":
+		return leagueScoreboard(client, league, weekArg(args))
+	case "m":
+		return teamMatchups(client, league)
+	case "r":
+		return teamRosters(client, league, weekArg(args))
+	case "s":
+		if len(args) != 2 {
+			log.Fatal("gimme suffix")
+		}
+		return leagueStandings(client, league, args[1])
+	default:
+		return getToStdout(client, args[0])
+	}
 }
 
+var (
+	leagueCfg = flag.String("league", "", "league config JSON file; empty means the default 12-team league")
+	outDir    = flag.String("out_dir", "", "directory for downloaded files; empty means the league's yahoo_dir")
+	baseURL   = flag.String("base_url", yahoo.DefaultBaseURL, "API base URL, eg a fake server's; OAuth tokens are only sent to Yahoo")
+	replayDir = flag.String("replay_dir", "", "if set, serve responses from fixtures in this directory instead of the network")
+	recordDir = flag.String("record_dir", "", "if set, save responses as fixtures in this directory")
+)
+
 const (
 	leagueFile  = "league.txt"
 	secretsFile = "client_secrets.json"
@@ -119,29 +186,60 @@ const (
 )
 
 func main() {
+	flag.Parse()
 	ctx := context.Background()
 
 	home, _ := os.UserHomeDir()
 
-	b, err := os.ReadFile(path.Join(home, leagueFile))
+	cfg, err := fantasy.LoadLeague(*leagueCfg)
 	if err != nil {
 		log.Fatal(err)
 	}
-	league := string(bytes.TrimSpace(b))
-
-	conf, err := yahoo.ReadConfig(path.Join(home, secretsFile))
-	if err != nil {
-		log.Fatal(err)
+	if *outDir == "" {
+		*outDir = cfg.YahooDir
+	}
+	if *outDir == "" {
+		*outDir = home
 	}
 
-	tok, err := yahoo.ReadToken(path.Join(home, tokenFile))
-	if err != nil {
-		log.Fatal(err)
+	league := cfg.YahooLeague
+	if league == "" {
+		b, err := os.ReadFile(path.Join(home, leagueFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+		league = string(bytes.TrimSpace(b))
 	}
 
-	client := oauth2.NewClient(ctx, NewCachingTokenSource(path.Join(home, tokenFile), conf, tok))
+	var hc *http.Client
+	switch {
+	case *replayDir != "":
+		hc = &http.Client{Transport: &yahootest.Transport{Dir: *replayDir}}
+	case *baseURL != yahoo.DefaultBaseURL:
+		hc = &http.Client{}
+	default:
+		conf, err := yahoo.ReadConfig(path.Join(home, secretsFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+		tok, err := yahoo.ReadToken(path.Join(home, tokenFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+		hc = oauth2.NewClient(ctx, NewCachingTokenSource(path.Join(home, tokenFile), conf, tok))
+	}
+	if *recordDir != "" {
+		base := hc.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		hc.Transport = &yahootest.Transport{Dir: *recordDir, Base: base}
+	}
+	client := yahoo.NewClient(hc)
+	client.BaseURL = *baseURL
 
-	if len(os.Args) == 1 {
+	args := flag.Args()
+	if len(args) == 0 {
 		scanner := bufio.NewScanner(os.Stdin)
 		fmt.Printf("Enter URI: ")
 		for scanner.Scan() {
@@ -155,41 +253,7 @@ func main() {
 		return
 	}
 
-	switch os.Args[1] {
-	case "a":
-		if len(os.Args) != 3 {
-			log.Fatal("gimme suffix")
-		}
-		err = leaguePlayersAvailable(client, league, os.Args[2])
-	case "b":
-		if len(os.Args) != 3 {
-			log.Fatal("gimme week")
-		}
-		week, err := strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatal(err)
-		}
-		err = leagueScoreboard(client, league, week)
-	case "m":
-		err = teamMatchups(client, league)
-	case "r":
-		if len(os.Args) != 3 {
-			log.Fatal("gimme week")
-		}
-		week, err := strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatal(err)
-		}
-		err = teamRosters(client, league, week)
-	case "s":
-		if len(os.Args) != 3 {
-			log.Fatal("gimme suffix")
-		}
-		err = leagueStandings(client, league, os.Args[2])
-	default:
-		err = getToStdout(client, os.Args[1])
-	}
-	if err != nil {
+	if err := run(client, league, args); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dbtleonia/fantasy/yahoo"
+	"github.com/dbtleonia/fantasy/yahoo/yahootest"
+)
+
+const testLeague = "449.l.12345"
+
+func TestRun(t *testing.T) {
+	srv := yahootest.NewServer("../testdata")
+	defer srv.Close()
+	served := yahoo.NewClient(srv.Client())
+	served.BaseURL = srv.URL + yahootest.APIPath
+	replayed := yahoo.NewClient(&http.Client{Transport: &yahootest.Transport{Dir: "../testdata"}})
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"a", "pre"}, []string{"league_players_available_pre_0000.json"}},
+		{[]string{"b", "3"}, []string{"league_scoreboard_week03.json"}},
+		{[]string{"m"}, []string{"team_matchups_01.json", "team_matchups_02.json"}},
+		{[]string{"r", "3"}, []string{"team_rosters_week03_01.json", "team_rosters_week03_02.json"}},
+		{[]string{"s", "pre"}, []string{"league_settings_pre.json", "league_standings_pre.json"}},
+	}
+	for name, client := range map[string]*yahoo.Client{"served": served, "replayed": replayed} {
+		for _, test := range tests {
+			*outDir = t.TempDir()
+			if err := run(client, testLeague, test.args); err != nil {
+				t.Errorf("%s %q: %s", name, test.args, err)
+				continue
+			}
+			entries, err := os.ReadDir(*outDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Name())
+				if _, err := yahoo.ReadResponse(path.Join(*outDir, e.Name())); err != nil {
+					t.Errorf("%s %q: %s", name, test.args, err)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s %q: got files %q, want %q", name, test.args, got, test.want)
+			}
+		}
+	}
+}
+
+func TestRunKeepsGoodData(t *testing.T) {
+	srv := yahootest.NewServer("../testdata")
+	defer srv.Close()
+	client := yahoo.NewClient(srv.Client())
+	client.BaseURL = srv.URL + yahootest.APIPath
+
+	*outDir = t.TempDir()
+	filename := path.Join(*outDir, "league_scoreboard_week04.json")
+	if err := os.WriteFile(filename, []byte("good\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// There's no fixture for week 4, so the fake server returns 404.
+	if err := run(client, testLeague, []string{"b", "4"}); err == nil {
+		t.Error("got no error for 404")
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "good\n" {
+		t.Errorf("got %q after failed fetch, want it unchanged", b)
+	}
+	if entries, _ := os.ReadDir(*outDir); len(entries) != 1 {
+		t.Errorf("got %d files, want 1", len(entries))
+	}
+}
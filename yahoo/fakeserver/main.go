@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dbtleonia/fantasy/yahoo/yahootest"
+)
+
+var (
+	addr  = flag.String("addr", "localhost:8080", "address to listen on")
+	dir   = flag.String("dir", "yahoo/testdata", "directory of recorded fixtures, eg from download -record_dir")
+	delay = flag.Duration("delay", 0, "time to wait before each response, to mimic a slow Yahoo")
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	h := yahootest.Handler(*dir)
+	http.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(*delay)
+		log.Printf("%s %s", r.Method, r.URL)
+		h.ServeHTTP(w, r)
+	}))
+	log.Printf("Serving %s; use -base_url http://%s%s", *dir, *addr, yahootest.APIPath)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+
+	"github.com/dbtleonia/fantasy/yahoo"
+)
+
+var (
+	out = flag.String("out", "", "output CSV file; empty means stdout")
+)
+
+const usage = `Usage: %s [<flags>] <command> <json>...
+
+Commands:
+  scoreboard <scoreboard-json>                points per matchup
+  standings <settings-json> <standings-json>  record and points per team
+  matchups <team-matchups-json>...            opponent per team and week
+  rosters <team-roster-json>...               points per rostered player
+  players <league-players-json>...            points per player, eg available ones
+
+`
+
+func readLeague(filename string) *yahoo.League {
+	r, err := yahoo.ReadResponse(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if r.FantasyContent.League == nil {
+		log.Fatalf("%s: no league", filename)
+	}
+	return r.FantasyContent.League
+}
+
+func readTeam(filename string) *yahoo.Team {
+	r, err := yahoo.ReadResponse(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if r.FantasyContent.Team == nil {
+		log.Fatalf("%s: no team", filename)
+	}
+	return r.FantasyContent.Team
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, usage, os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	args := flag.Args()
+	if len(args) < 2 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	cmd, files := args[0], args[1:]
+
+	var records [][]string
+	switch cmd {
+	case "scoreboard":
+		if len(files) != 1 {
+			log.Fatal("scoreboard takes one file")
+		}
+		l := readLeague(files[0])
+		if l.Scoreboard == nil {
+			log.Fatalf("%s: no scoreboard", files[0])
+		}
+		var err error
+		records, err = yahoo.ScoreboardRecords(l.Scoreboard)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "standings":
+		if len(files) != 2 {
+			log.Fatal("standings takes settings and standings files")
+		}
+		settings, standings := readLeague(files[0]), readLeague(files[1])
+		if settings.Settings == nil {
+			log.Fatalf("%s: no settings", files[0])
+		}
+		if standings.Standings == nil {
+			log.Fatalf("%s: no standings", files[1])
+		}
+		var teams []*yahoo.Team
+		for i := range standings.Standings.Teams {
+			teams = append(teams, &standings.Standings.Teams[i].Team)
+		}
+		var err error
+		records, err = yahoo.StandingsRecords(settings.Settings, teams)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "matchups", "rosters":
+		var teams []*yahoo.Team
+		for _, f := range files {
+			teams = append(teams, readTeam(f))
+		}
+		if cmd == "matchups" {
+			var err error
+			records, err = yahoo.MatchupsRecords(teams)
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			records = yahoo.RosterRecords(teams)
+		}
+	case "players":
+		var players []*yahoo.Player
+		for _, f := range files {
+			l := readLeague(f)
+			for i := range l.Players {
+				players = append(players, &l.Players[i].Player)
+			}
+		}
+		records = yahoo.PlayersRecords(players)
+	default:
+		log.Fatalf("unknown command %q", cmd)
+	}
+
+	if *out == "" {
+		if err := csv.NewWriter(os.Stdout).WriteAll(records); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := writeFile(*out, records); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %s\n", *out)
+}
+
+// writeFile writes to a temporary file and renames it into place, so a
+// failure never leaves a partial file behind.
+func writeFile(filename string, records [][]string) error {
+	f, err := os.CreateTemp(path.Dir(filename), "."+path.Base(filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	err = csv.NewWriter(f).WriteAll(records)
+	if err1 := f.Close(); err1 != nil && err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
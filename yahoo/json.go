@@ -0,0 +1,69 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Yahoo's JSON encodes some numbers as strings and others as numbers,
+// not always consistently.  These types accept either.
+
+type Number float64
+
+func (n *Number) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(b, &f); err != nil {
+			return err
+		}
+		*n = Number(f)
+		return nil
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
+}
+
+type Int int
+
+func (n *Int) UnmarshalJSON(b []byte) error {
+	var f Number
+	if err := f.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*n = Int(f)
+	return nil
+}
+
+type Bool bool
+
+// UnmarshalJSON accepts true/false, 1/0 and their string forms.  An
+// empty string is false.
+func (v *Bool) UnmarshalJSON(b []byte) error {
+	var x interface{}
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+	switch x := x.(type) {
+	case bool:
+		*v = Bool(x)
+	case float64:
+		*v = x != 0
+	case string:
+		*v = x != "" && x != "0" && x != "false"
+	case nil:
+		*v = false
+	default:
+		return fmt.Errorf("cannot decode %s as bool", b)
+	}
+	return nil
+}
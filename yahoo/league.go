@@ -0,0 +1,125 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Response is the top level of every json_f response.
+type Response struct {
+	FantasyContent Content `json:"fantasy_content"`
+}
+
+type Content struct {
+	League  *League `json:"league"`
+	Team    *Team   `json:"team"`
+	Leagues []struct {
+		League League `json:"league"`
+	} `json:"leagues"` // multi-league requests, eg transactions across seasons
+}
+
+type League struct {
+	LeagueKey string    `json:"league_key"`
+	Name      string    `json:"name"`
+	NumTeams  Int       `json:"num_teams"`
+	Season    Int       `json:"season"`
+	Settings  *Settings `json:"settings"`
+
+	// Present when requested as subresources.
+	Teams []struct {
+		Team Team `json:"team"`
+	} `json:"teams"`
+	Transactions []struct {
+		Transaction Transaction `json:"transaction"`
+	} `json:"transactions"`
+	DraftResults []struct {
+		DraftResult DraftResult `json:"draft_result"`
+	} `json:"draft_results"`
+	Players []struct {
+		Player Player `json:"player"`
+	} `json:"players"`
+	Standings *struct {
+		Teams []struct {
+			Team Team `json:"team"`
+		} `json:"teams"`
+	} `json:"standings"`
+	Scoreboard *Scoreboard `json:"scoreboard"`
+}
+
+type Scoreboard struct {
+	Week     Int `json:"week"`
+	Matchups []struct {
+		Matchup Matchup `json:"matchup"`
+	} `json:"matchups"`
+}
+
+type Matchup struct {
+	Week   Int    `json:"week"`
+	Status string `json:"status"` // preevent, midevent or postevent
+	Teams  []struct {
+		Team Team `json:"team"`
+	} `json:"teams"`
+}
+
+type Settings struct {
+	MaxKeepers      Int `json:"max_keepers"` // not present for all leagues
+	RosterPositions []struct {
+		RosterPosition RosterPosition `json:"roster_position"`
+	} `json:"roster_positions"`
+	StatCategories struct {
+		Stats []struct {
+			Stat StatCategory `json:"stat"`
+		} `json:"stats"`
+	} `json:"stat_categories"`
+	StatModifiers struct {
+		Stats []struct {
+			Stat StatModifier `json:"stat"`
+		} `json:"stats"`
+	} `json:"stat_modifiers"`
+	Divisions []struct {
+		Division Division `json:"division"`
+	} `json:"divisions"`
+}
+
+type RosterPosition struct {
+	Position     string `json:"position"` // eg QB, W/R/T, BN, IR
+	PositionType string `json:"position_type"`
+	Count        Int    `json:"count"`
+}
+
+type StatCategory struct {
+	StatID       Int    `json:"stat_id"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"display_name"`
+	PositionType string `json:"position_type"` // O, K or DT
+}
+
+type StatModifier struct {
+	StatID  Int    `json:"stat_id"`
+	Value   Number `json:"value"`
+	Bonuses []struct {
+		Bonus struct {
+			Target Number `json:"target"`
+			Points Number `json:"points"`
+		} `json:"bonus"`
+	} `json:"bonuses"`
+}
+
+type Division struct {
+	DivisionID Int    `json:"division_id"`
+	Name       string `json:"name"`
+}
+
+// ReadResponse decodes a json_f response saved to a file.
+func ReadResponse(filename string) (*Response, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var r Response
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return &r, nil
+}
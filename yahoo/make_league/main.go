@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/yahoo"
+)
+
+var (
+	maxKeepers = flag.Int("max_keepers", 3, "max keepers per manager, if not in the league settings")
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [<flags>] <league-settings-json> <league-json>\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	r, err := yahoo.ReadResponse(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if r.FantasyContent.League == nil {
+		log.Fatalf("%s: no league", flag.Arg(0))
+	}
+	league, warnings, err := yahoo.LeagueConfig(r.FantasyContent.League, *maxKeepers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, w := range warnings {
+		log.Print(w)
+	}
+
+	if err := fantasy.WriteLeague(league, flag.Arg(1)); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %s\n", flag.Arg(1))
+}
@@ -1,13 +1,20 @@
 package yahoo
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/endpoints"
 )
 
+// ReadConfig reads an app's client ID and secret, and optionally its
+// RedirectURL, from a JSON file.
 func ReadConfig(filename string) (*oauth2.Config, error) {
 	b, err := os.ReadFile(filename)
 	if err != nil {
@@ -17,7 +24,6 @@ func ReadConfig(filename string) (*oauth2.Config, error) {
 	if err := json.Unmarshal(b, &conf); err != nil {
 		return nil, err
 	}
-	conf.RedirectURL = "oob"
 	conf.Endpoint = endpoints.Yahoo
 	return &conf, nil
 }
@@ -42,3 +48,70 @@ func WriteToken(tok *oauth2.Token, filename string) error {
 	b = append(b, []byte("\n")...)
 	return os.WriteFile(filename, b, 0600)
 }
+
+// LoopbackToken gets a token by sending the user's browser to Yahoo and
+// receiving the redirect on ln, which must listen at conf.RedirectURL.
+// visit is given the URL to send the browser to.  The redirect must
+// carry the state we sent, and the code exchange uses PKCE.  Requests
+// with no state, code or error, eg for /favicon.ico, are ignored.
+func LoopbackToken(ctx context.Context, conf *oauth2.Config, ln net.Listener, visit func(authURL string)) (*oauth2.Token, error) {
+	redirect, err := url.Parse(conf.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	state := oauth2.GenerateVerifier() // just a random string
+	verifier := oauth2.GenerateVerifier()
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	pattern := redirect.Path
+	if pattern == "" {
+		pattern = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") == "" && q.Get("code") == "" && q.Get("error") == "" {
+			http.NotFound(w, r)
+			return
+		}
+		var res result
+		switch {
+		case q.Get("state") != state:
+			res.err = fmt.Errorf("redirect has wrong state")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization failed: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("redirect has no code")
+		default:
+			res.code = q.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorized. You can close this window.")
+		}
+		select {
+		case results <- res:
+		default: // already have a result
+		}
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	visit(conf.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)))
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return conf.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+	}
+}
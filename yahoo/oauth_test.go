@@ -0,0 +1,118 @@
+package yahoo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestLoopbackToken(t *testing.T) {
+	// The fake token endpoint checks the PKCE verifier against the
+	// challenge the browser saw.
+	var challenge string
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "abc" || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"at","refresh_token":"rt","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer tokens.Close()
+
+	tests := []struct {
+		desc    string
+		path    string                    // of the redirect URL
+		query   func(state string) string // of the redirect
+		wantErr bool
+	}{
+		{"ok", "/callback", func(state string) string { return "code=abc&state=" + state }, false},
+		{"ok at root", "/", func(state string) string { return "code=abc&state=" + state }, false},
+		{"wrong state", "/callback", func(state string) string { return "code=abc&state=x" + state }, true},
+		{"denied", "/callback", func(state string) string { return "error=access_denied&state=" + state }, true},
+		{"wrong code", "/callback", func(state string) string { return "code=xyz&state=" + state }, true},
+	}
+	for _, test := range tests {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := &oauth2.Config{
+			ClientID:    "id",
+			RedirectURL: "http://" + ln.Addr().String() + test.path,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   "https://auth.example.com/auth",
+				TokenURL:  tokens.URL,
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		}
+		browser := func(authURL string) {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := u.Query()
+			challenge = q.Get("code_challenge")
+			if q.Get("code_challenge_method") != "S256" {
+				t.Errorf("%s: got challenge method %q", test.desc, q.Get("code_challenge_method"))
+			}
+			go func() {
+				// Stray requests before the redirect don't decide the
+				// result.
+				for _, u := range []string{
+					"http://" + ln.Addr().String() + "/favicon.ico",
+					q.Get("redirect_uri"),
+					q.Get("redirect_uri") + "?" + test.query(q.Get("state")),
+				} {
+					resp, err := http.Get(u)
+					if err == nil {
+						resp.Body.Close()
+					}
+				}
+			}()
+		}
+		tok, err := LoopbackToken(context.Background(), conf, ln, browser)
+		ln.Close()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: got no error", test.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", test.desc, err)
+		} else if tok.AccessToken != "at" || tok.RefreshToken != "rt" {
+			t.Errorf("%s: got token %+v", test.desc, tok)
+		}
+	}
+}
+
+func TestLoopbackTokenTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	conf := &oauth2.Config{
+		ClientID:    "id",
+		RedirectURL: "http://" + ln.Addr().String() + "/callback",
+		Endpoint:    oauth2.Endpoint{AuthURL: "https://auth.example.com/auth"},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// The browser never redirects.
+	if _, err := LoopbackToken(ctx, conf, ln, func(string) {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,151 @@
+package yahoo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// The functions below flatten responses into CSV records, header first.
+
+func total(p *Points) string {
+	if p == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(p.Total), 'f', -1, 64)
+}
+
+// ScoreboardRecords returns a row per matchup with each team's points
+// and projected points.
+func ScoreboardRecords(sb *Scoreboard) ([][]string, error) {
+	records := [][]string{{
+		"team1",
+		"team1 points",
+		"team1 projected points",
+		"team2",
+		"team2 points",
+		"team2 projected points",
+	}}
+	for _, m := range sb.Matchups {
+		if n := len(m.Matchup.Teams); n != 2 {
+			return nil, fmt.Errorf("week %d matchup has %d teams, want 2", m.Matchup.Week, n)
+		}
+		var record []string
+		for _, t := range m.Matchup.Teams {
+			record = append(record, t.Team.Name, total(t.Team.TeamPoints), total(t.Team.TeamProjectedPoints))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// StandingsRecords returns a row per team ordered by division, with
+// division names from the league settings.
+func StandingsRecords(settings *Settings, standings []*Team) ([][]string, error) {
+	divisions := make(map[Int]string)
+	for _, d := range settings.Divisions {
+		divisions[d.Division.DivisionID] = d.Division.Name
+	}
+	teams := append([]*Team(nil), standings...)
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].DivisionID < teams[j].DivisionID
+	})
+
+	records := [][]string{{
+		"division",
+		"team",
+		"points",
+		"wins",
+		"losses",
+		"ties",
+	}}
+	for _, t := range teams {
+		division, ok := divisions[t.DivisionID]
+		if !ok {
+			return nil, fmt.Errorf("team %s: no division %d", t.Name, t.DivisionID)
+		}
+		if t.TeamStandings == nil {
+			return nil, fmt.Errorf("team %s: no standings", t.Name)
+		}
+		o := t.TeamStandings.OutcomeTotals
+		records = append(records, []string{
+			division,
+			t.Name,
+			total(t.TeamPoints),
+			strconv.Itoa(int(o.Wins)),
+			strconv.Itoa(int(o.Losses)),
+			strconv.Itoa(int(o.Ties)),
+		})
+	}
+	return records, nil
+}
+
+// MatchupsRecords returns a row per team and week with the opponent.
+func MatchupsRecords(teams []*Team) ([][]string, error) {
+	records := [][]string{{
+		"team",
+		"week",
+		"opponent",
+	}}
+	for _, t := range teams {
+		for _, m := range t.Matchups {
+			ts := m.Matchup.Teams
+			if len(ts) != 2 {
+				return nil, fmt.Errorf("team %s: week %d matchup has %d teams, want 2", t.Name, m.Matchup.Week, len(ts))
+			}
+			records = append(records, []string{
+				ts[0].Team.Name,
+				strconv.Itoa(int(m.Matchup.Week)),
+				ts[1].Team.Name,
+			})
+		}
+	}
+	return records, nil
+}
+
+// RosterRecords returns a row per rostered player with their lineup
+// position and points.
+func RosterRecords(teams []*Team) [][]string {
+	records := [][]string{{
+		"team",
+		"player",
+		"position",
+		"points",
+	}}
+	for _, t := range teams {
+		if t.Roster == nil {
+			continue
+		}
+		for _, p := range t.Roster.Players {
+			var pos string
+			if p.Player.SelectedPosition != nil {
+				pos = p.Player.SelectedPosition.Position
+			}
+			records = append(records, []string{
+				t.Name,
+				p.Player.Name.Full,
+				pos,
+				total(p.Player.PlayerPoints),
+			})
+		}
+	}
+	return records
+}
+
+// PlayersRecords returns a row per player with their position and
+// points.
+func PlayersRecords(players []*Player) [][]string {
+	records := [][]string{{
+		"player",
+		"position",
+		"points",
+	}}
+	for _, p := range players {
+		records = append(records, []string{
+			p.Name.Full,
+			p.PrimaryPosition,
+			total(p.PlayerPoints),
+		})
+	}
+	return records
+}
@@ -0,0 +1,124 @@
+package yahoo
+
+import (
+	"path"
+	"reflect"
+	"testing"
+)
+
+func readTestLeague(t *testing.T, file string) *League {
+	r, err := ReadResponse(path.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.FantasyContent.League
+}
+
+func TestRecords(t *testing.T) {
+	settings := readTestLeague(t, "league_449.l.12345_settings.json").Settings
+	standings := readTestLeague(t, "league_449.l.12345_standings.json").Standings
+	var teams []*Team
+	for i := range standings.Teams {
+		teams = append(teams, &standings.Teams[i].Team)
+	}
+	got, err := StandingsRecords(settings, teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"division", "team", "points", "wins", "losses", "ties"},
+		{"East", "Bob's Bombers", "412.36", "3", "0", "0"},
+		{"West", "Al's Aces", "355", "1", "1", "1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StandingsRecords: got %q, want %q", got, want)
+	}
+
+	sb := readTestLeague(t, "league_449.l.12345_scoreboard_week=3.json").Scoreboard
+	got, err = ScoreboardRecords(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = [][]string{
+		{"team1", "team1 points", "team1 projected points", "team2", "team2 points", "team2 projected points"},
+		{"Al's Aces", "101.2", "98.55", "Bob's Bombers", "88", "104.1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoreboardRecords: got %q, want %q", got, want)
+	}
+
+	sb.Matchups[0].Matchup.Teams = sb.Matchups[0].Matchup.Teams[:1]
+	if _, err := ScoreboardRecords(sb); err == nil {
+		t.Errorf("ScoreboardRecords with a one-team matchup succeeded, want error")
+	}
+}
+
+func readTestTeam(t *testing.T, file string) *Team {
+	r, err := ReadResponse(path.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.FantasyContent.Team
+}
+
+func TestMatchupsRecords(t *testing.T) {
+	teams := []*Team{
+		readTestTeam(t, "team_449.l.12345.t.1_matchups.json"),
+		readTestTeam(t, "team_449.l.12345.t.2_matchups.json"),
+	}
+	got, err := MatchupsRecords(teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"team", "week", "opponent"},
+		{"Al's Aces", "1", "Cy's Cyclones"},
+		{"Al's Aces", "2", "Bob's Bombers"},
+		{"Bob's Bombers", "1", "Di's Dynamos"},
+		{"Bob's Bombers", "2", "Al's Aces"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchupsRecords: got %q, want %q", got, want)
+	}
+
+	// A bye week has one team.
+	teams[0].Matchups[0].Matchup.Teams = teams[0].Matchups[0].Matchup.Teams[:1]
+	if _, err := MatchupsRecords(teams); err == nil {
+		t.Errorf("MatchupsRecords with a one-team matchup succeeded, want error")
+	}
+}
+
+func TestRosterRecords(t *testing.T) {
+	teams := []*Team{
+		readTestTeam(t, "team_449.l.12345.t.1_roster_week=3_players_stats.json"),
+		readTestTeam(t, "team_449.l.12345.t.2_roster_week=3_players_stats.json"),
+		{Name: "No Roster"},
+	}
+	got := RosterRecords(teams)
+	want := [][]string{
+		{"team", "player", "position", "points"},
+		{"Al's Aces", "Patrick Mahomes", "QB", "21.24"},
+		{"Al's Aces", "Travis Etienne", "BN", "0"},
+		{"Bob's Bombers", "Justin Jefferson", "W/R/T", "17.6"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RosterRecords: got %q, want %q", got, want)
+	}
+}
+
+func TestPlayersRecords(t *testing.T) {
+	l := readTestLeague(t, "league_449.l.12345_players_status=A_start=25_count=2_stats.json")
+	var players []*Player
+	for i := range l.Players {
+		players = append(players, &l.Players[i].Player)
+	}
+	got := PlayersRecords(players)
+	want := [][]string{
+		{"player", "position", "points"},
+		{"Zay Flowers", "WR", "38.9"},
+		{"Detroit", "DEF", "27"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlayersRecords: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package yahoo
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+var (
+	// Mapping of Yahoo roster positions -> schema letters.  IR slots
+	// are not drafted and are left out of the schema.
+	schemaLetters = map[string]byte{
+		"QB":    'Q',
+		"RB":    'R',
+		"WR":    'W',
+		"TE":    'T',
+		"W/R/T": 'X',
+		"K":     'K',
+		"DEF":   'D',
+		"BN":    'B',
+	}
+
+	// Mapping of Yahoo stat IDs -> StatLine stat names.
+	statNames = map[int]string{
+		1:  "pass_att",
+		2:  "pass_cmp",
+		4:  "pass_yds",
+		5:  "pass_td",
+		6:  "pass_int",
+		8:  "rush_att",
+		9:  "rush_yds",
+		10: "rush_td",
+		11: "rec",
+		12: "rec_yds",
+		13: "rec_td",
+		15: "ret_td",
+		16: "two_pt",
+		18: "fum_lost",
+		19: "fg_0_19",
+		20: "fg_20_29",
+		21: "fg_30_39",
+		22: "fg_40_49",
+		23: "fg_50",
+		29: "xp",
+		32: "sack",
+		35: "def_td",
+		36: "safety",
+		37: "blk_kick",
+		49: "dst_ret_td",
+	}
+
+	// Yahoo stat IDs for defensive takeaways, which score
+	// DSTScoring.Takeaway.
+	takeawayStats = map[int]bool{
+		33: true, // interception
+		34: true, // fumble recovery
+	}
+
+	// Yahoo stat IDs for points allowed buckets -> minimum points.
+	pointsAllowedStats = map[int]int{
+		50: 0,
+		51: 1,
+		52: 7,
+		53: 14,
+		54: 21,
+		55: 28,
+		56: 35,
+	}
+
+	// Yahoo stat IDs for yards allowed buckets -> minimum yards.  The
+	// first bucket is negative yards.
+	yardsAllowedStats = map[int]int{
+		70: -1,
+		71: 0,
+		72: 100,
+		73: 200,
+		74: 300,
+		75: 400,
+		76: 500,
+	}
+)
+
+// LeagueConfig converts Yahoo league settings into a league config.
+// Stat modifiers that have no StatLine equivalent are skipped and
+// returned as warnings.  If the settings don't say how many keepers are
+// allowed, maxKeepers is used.
+func LeagueConfig(l *League, maxKeepers int) (*fantasy.League, []string, error) {
+	if l.Settings == nil {
+		return nil, nil, fmt.Errorf("league %s has no settings", l.LeagueKey)
+	}
+	var warnings []string
+
+	var schema []byte
+	for _, rp := range l.Settings.RosterPositions {
+		pos := rp.RosterPosition.Position
+		if pos == "IR" {
+			continue
+		}
+		ch, ok := schemaLetters[pos]
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported roster position %q", pos)
+		}
+		for i := 0; i < int(rp.RosterPosition.Count); i++ {
+			schema = append(schema, ch)
+		}
+	}
+
+	names := make(map[int]string)
+	for _, sc := range l.Settings.StatCategories.Stats {
+		names[int(sc.Stat.StatID)] = sc.Stat.DisplayName
+	}
+
+	scoring := fantasy.Scoring{
+		Stats: make(map[string]float64),
+	}
+	for _, sm := range l.Settings.StatModifiers.Stats {
+		id := int(sm.Stat.StatID)
+		value := float64(sm.Stat.Value)
+		if stat, ok := statNames[id]; ok {
+			scoring.Stats[stat] = value
+			for _, b := range sm.Stat.Bonuses {
+				scoring.Bonuses = append(scoring.Bonuses, fantasy.Bonus{
+					Stat:   stat,
+					Min:    float64(b.Bonus.Target),
+					Points: float64(b.Bonus.Points),
+				})
+			}
+			continue
+		}
+		if takeawayStats[id] {
+			if scoring.DST.Takeaway != 0 && scoring.DST.Takeaway != value {
+				warnings = append(warnings, fmt.Sprintf("stat %d (%s): takeaways score differently, using %g", id, names[id], scoring.DST.Takeaway))
+				continue
+			}
+			scoring.DST.Takeaway = value
+			continue
+		}
+		if min, ok := pointsAllowedStats[id]; ok {
+			scoring.DST.PointsAllowed = append(scoring.DST.PointsAllowed, fantasy.Tier{Min: min, Points: value})
+			continue
+		}
+		if min, ok := yardsAllowedStats[id]; ok {
+			scoring.DST.YardsAllowed = append(scoring.DST.YardsAllowed, fantasy.Tier{Min: min, Points: value})
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("stat %d (%s): not supported, skipping %g points", id, names[id], value))
+	}
+	for _, ts := range []fantasy.Tiers{scoring.DST.PointsAllowed, scoring.DST.YardsAllowed} {
+		sort.Slice(ts, func(i, j int) bool { return ts[i].Min < ts[j].Min })
+	}
+
+	if l.Settings.MaxKeepers > 0 {
+		maxKeepers = int(l.Settings.MaxKeepers)
+	}
+
+	flex := make(map[string]string)
+	if bytes.IndexByte(schema, 'X') >= 0 {
+		flex["X"] = "RTW"
+	}
+
+	return &fantasy.League{
+		Teams:      int(l.NumTeams),
+		Rounds:     len(schema),
+		Schema:     string(schema),
+		Flex:       flex,
+		MaxKeepers: maxKeepers,
+		Scoring:    scoring,
+	}, warnings, nil
+}
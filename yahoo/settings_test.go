@@ -0,0 +1,70 @@
+package yahoo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestLeagueConfig(t *testing.T) {
+	l := readTestLeague(t, "league_449.l.12345_settings.json")
+	got, warnings, err := LeagueConfig(l, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &fantasy.League{
+		Teams:      4,
+		Rounds:     7,
+		Schema:     "QXBBBBB",
+		Flex:       map[string]string{"X": "RTW"},
+		MaxKeepers: 2,
+		Scoring: fantasy.Scoring{
+			Stats:   map[string]float64{"pass_yds": 0.04, "pass_td": 4},
+			Bonuses: []fantasy.Bonus{{Stat: "pass_td", Min: 3, Points: 1.5}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) || len(warnings) != 0 {
+		t.Errorf("got %+v, warnings %q; want %+v", got, warnings, want)
+	}
+}
+
+func TestLeagueConfigReturnTDs(t *testing.T) {
+	l := &League{Settings: &Settings{}}
+	for _, id := range []Int{15, 49} {
+		var sm struct {
+			Stat StatModifier `json:"stat"`
+		}
+		sm.Stat = StatModifier{StatID: id, Value: Number(id)}
+		l.Settings.StatModifiers.Stats = append(l.Settings.StatModifiers.Stats, sm)
+	}
+	got, _, err := LeagueConfig(l, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]float64{"ret_td": 15, "dst_ret_td": 49}; !reflect.DeepEqual(got.Scoring.Stats, want) {
+		t.Errorf("got stats %v, want %v", got.Scoring.Stats, want)
+	}
+}
+
+func TestLeagueConfigAllowedTiers(t *testing.T) {
+	l := &League{Settings: &Settings{}}
+	for id, value := range map[Int]Number{56: -4, 50: 10, 53: 1, 76: -5, 70: 5, 73: 0, 71: 3} {
+		var sm struct {
+			Stat StatModifier `json:"stat"`
+		}
+		sm.Stat = StatModifier{StatID: id, Value: value}
+		l.Settings.StatModifiers.Stats = append(l.Settings.StatModifiers.Stats, sm)
+	}
+	got, warnings, err := LeagueConfig(l, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fantasy.DSTScoring{
+		PointsAllowed: fantasy.Tiers{{Min: 0, Points: 10}, {Min: 14, Points: 1}, {Min: 35, Points: -4}},
+		YardsAllowed:  fantasy.Tiers{{Min: -1, Points: 5}, {Min: 0, Points: 3}, {Min: 200, Points: 0}, {Min: 500, Points: -5}},
+	}
+	if !reflect.DeepEqual(got.Scoring.DST, want) || len(warnings) != 0 {
+		t.Errorf("got DST %+v, warnings %q; want %+v", got.Scoring.DST, warnings, want)
+	}
+}
@@ -0,0 +1,108 @@
+package yahoo
+
+type Team struct {
+	TeamKey    string `json:"team_key"`
+	TeamID     Int    `json:"team_id"`
+	Name       string `json:"name"`
+	DivisionID Int    `json:"division_id"`
+	Managers   []struct {
+		Manager Manager `json:"manager"`
+	} `json:"managers"`
+
+	// Players is set for league/teams/players requests and Roster for
+	// team/roster requests.
+	Players []struct {
+		Player Player `json:"player"`
+	} `json:"players"`
+	Roster *struct {
+		Players []struct {
+			Player Player `json:"player"`
+		} `json:"players"`
+	} `json:"roster"`
+
+	// Present for standings, scoreboard and matchups requests.
+	TeamPoints          *Points        `json:"team_points"`
+	TeamProjectedPoints *Points        `json:"team_projected_points"`
+	TeamStandings       *TeamStandings `json:"team_standings"`
+	Matchups            []struct {
+		Matchup Matchup `json:"matchup"`
+	} `json:"matchups"`
+}
+
+// Points is a total over a week or season.
+type Points struct {
+	CoverageType string `json:"coverage_type"` // week or season
+	Week         Int    `json:"week"`
+	Total        Number `json:"total"`
+}
+
+type TeamStandings struct {
+	Rank          Int `json:"rank"`
+	OutcomeTotals struct {
+		Wins       Int    `json:"wins"`
+		Losses     Int    `json:"losses"`
+		Ties       Int    `json:"ties"`
+		Percentage Number `json:"percentage"`
+	} `json:"outcome_totals"`
+	PointsFor     Number `json:"points_for"`
+	PointsAgainst Number `json:"points_against"`
+}
+
+type Manager struct {
+	ManagerID Int    `json:"manager_id"`
+	GUID      string `json:"guid"`
+	Nickname  string `json:"nickname"`
+}
+
+type Player struct {
+	PlayerKey string `json:"player_key"` // eg nfl.p.30123
+	PlayerID  Int    `json:"player_id"`
+	Name      struct {
+		Full string `json:"full"`
+	} `json:"name"`
+	EditorialTeamAbbr string `json:"editorial_team_abbr"`
+	DisplayPosition   string `json:"display_position"` // eg WR,TE
+	PrimaryPosition   string `json:"primary_position"`
+	URL               string `json:"url"`
+
+	// Present only in some contexts.
+	TransactionData *struct {
+		Type string `json:"type"` // add, drop or trade
+	} `json:"transaction_data"`
+	IsKeeper *struct {
+		Kept Bool `json:"kept"`
+	} `json:"is_keeper"`
+	SelectedPosition *struct {
+		Position string `json:"position"` // eg BN
+	} `json:"selected_position"`
+	PlayerPoints *Points `json:"player_points"`
+	PlayerStats  *struct {
+		Stats []struct {
+			Stat Stat `json:"stat"`
+		} `json:"stats"`
+	} `json:"player_stats"`
+}
+
+type Stat struct {
+	StatID Int    `json:"stat_id"`
+	Value  Number `json:"value"`
+}
+
+type Transaction struct {
+	TransactionKey string `json:"transaction_key"`
+	Type           string `json:"type"` // eg add/drop, trade
+	Timestamp      Int    `json:"timestamp"`
+	Players        []struct {
+		Player Player `json:"player"`
+	} `json:"players"`
+}
+
+// DraftResult is one pick.  Player is set only for
+// draftresults/players requests.
+type DraftResult struct {
+	Pick      Int     `json:"pick"`
+	Round     Int     `json:"round"`
+	TeamKey   string  `json:"team_key"`
+	PlayerKey string  `json:"player_key"`
+	Player    *Player `json:"player"`
+}
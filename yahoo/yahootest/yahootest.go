@@ -0,0 +1,100 @@
+// Package yahootest records Yahoo Fantasy API responses and serves them
+// back, for testing without OAuth or the network.
+package yahootest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+)
+
+// APIPath is the path of the API on Yahoo's server.  Handler serves
+// fixtures both under it and at the root.
+const APIPath = "/fantasy/v2"
+
+// FixtureName returns the file name for the response to a resource
+// path, eg league_449.l.12345_players_status=A_count=25_stats.json for
+// /league/449.l.12345/players;status=A;count=25/stats.
+func FixtureName(uriPath string) string {
+	uriPath = strings.TrimPrefix(uriPath, APIPath)
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		case strings.ContainsRune(".,=-", r):
+			return r
+		}
+		return '_'
+	}, strings.TrimPrefix(uriPath, "/"))
+	return name + ".json"
+}
+
+// Handler serves the fixtures in dir as a fake Yahoo API.  Requests
+// must ask for json_f; those without a fixture get 404.
+func Handler(dir string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Query().Get("format") != "json_f" {
+			http.Error(w, "format must be json_f", http.StatusBadRequest)
+			return
+		}
+		name := FixtureName(r.URL.Path)
+		b, err := os.ReadFile(path.Join(dir, name))
+		if os.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("no fixture %s", name), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+}
+
+// NewServer starts a fake Yahoo API serving the fixtures in dir.  Use
+// its URL plus APIPath as a client's base URL.
+func NewServer(dir string) *httptest.Server {
+	return httptest.NewServer(Handler(dir))
+}
+
+// Transport replays fixtures from Dir or, if Base is set, records them
+// there from real responses.  Only successful responses are recorded.
+type Transport struct {
+	Dir  string
+	Base http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Base == nil {
+		rec := httptest.NewRecorder()
+		Handler(t.Dir).ServeHTTP(rec, req)
+		resp := rec.Result()
+		resp.Request = req
+		return resp, nil
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err := os.WriteFile(path.Join(t.Dir, FixtureName(req.URL.Path)), b, 0600); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
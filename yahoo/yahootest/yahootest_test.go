@@ -0,0 +1,72 @@
+package yahootest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFixtureName(t *testing.T) {
+	tests := []struct {
+		uriPath, want string
+	}{
+		{"/league/449.l.1", "league_449.l.1.json"},
+		{"/fantasy/v2/league/449.l.1/teams", "league_449.l.1_teams.json"},
+		{"/league/449.l.1/players;status=A;start=25;count=25/stats", "league_449.l.1_players_status=A_start=25_count=25_stats.json"},
+		{"/league/449.l.1/transactions;types=add,drop", "league_449.l.1_transactions_types=add,drop.json"},
+	}
+	for _, test := range tests {
+		if got := FixtureName(test.uriPath); got != test.want {
+			t.Errorf("FixtureName(%q) = %q, want %q", test.uriPath, got, test.want)
+		}
+	}
+}
+
+func get(t *testing.T, tr http.RoundTripper, url string) (int, string) {
+	resp, err := (&http.Client{Transport: tr}).Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(b)
+}
+
+func TestRecordReplay(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == APIPath+"/league/449.l.1" {
+			io.WriteString(w, `{"fantasy_content":{}}`)
+			return
+		}
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer live.Close()
+	dir := t.TempDir()
+
+	record := &Transport{Dir: dir, Base: http.DefaultTransport}
+	get(t, record, live.URL+APIPath+"/league/449.l.1?format=json_f")
+	if code, _ := get(t, record, live.URL+APIPath+"/league/449.l.1/teams?format=json_f"); code != http.StatusServiceUnavailable {
+		t.Errorf("record: got %d, want 503", code)
+	}
+
+	replay := &Transport{Dir: dir}
+	tests := []struct {
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{"https://example.com" + APIPath + "/league/449.l.1?format=json_f", 200, `{"fantasy_content":{}}`},
+		{"https://example.com" + APIPath + "/league/449.l.1/teams?format=json_f", 404, "no fixture league_449.l.1_teams.json\n"},
+		{"https://example.com" + APIPath + "/league/449.l.1", 400, "format must be json_f\n"},
+	}
+	for _, test := range tests {
+		code, body := get(t, replay, test.url)
+		if code != test.wantCode || body != test.wantBody {
+			t.Errorf("replay %s: got %d %q, want %d %q", test.url, code, body, test.wantCode, test.wantBody)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package yards
+
+import (
+	"github.com/dbtleonia/fantasy"
+)
+
+// Bucket is one row of a collapsed DST scoring table: the expected
+// points for a defense that allows a given range of points, folding in
+// the yards allowed bonus seen historically for that range.
+type Bucket struct {
+	Label         string
+	Min           int
+	Games         int
+	PointsAllowed float64 // points from the points allowed tier
+	YardsAllowed  float64 // average points from the yards allowed tiers
+}
+
+func (b *Bucket) Expected() float64 {
+	return b.PointsAllowed + b.YardsAllowed
+}
+
+// DSTTable returns one Bucket per points allowed tier of the scoring.
+func DSTTable(games []*Game, s *fantasy.DSTScoring) []*Bucket {
+	buckets := make([]*Bucket, len(s.PointsAllowed))
+	for i, t := range s.PointsAllowed {
+		buckets[i] = &Bucket{
+			Label:         s.PointsAllowed.Label(i),
+			Min:           t.Min,
+			PointsAllowed: t.Points,
+		}
+	}
+	yards := make([]float64, len(buckets))
+	for _, g := range games {
+		for _, d := range g.Defenses() {
+			i := s.PointsAllowed.Index(d.PointsAllowed)
+			buckets[i].Games++
+			yards[i] += s.YardsAllowed.Points(d.YardsAllowed)
+		}
+	}
+	for i, b := range buckets {
+		if b.Games > 0 {
+			b.YardsAllowed = yards[i] / float64(b.Games)
+		}
+	}
+	return buckets
+}
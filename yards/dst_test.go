@@ -0,0 +1,41 @@
+package yards
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestDSTTable(t *testing.T) {
+	games, err := ReadGames("testdata/games.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 3 {
+		t.Fatalf("got %d games, want 3", len(games))
+	}
+	if g := games[0]; g.Winner != "KC" || g.Loser != "NE" || !g.WinnerAway || g.LoserYards != 371 || g.WinnerTurnovers != 1 {
+		t.Errorf("got first game %+v", g)
+	}
+
+	s := &fantasy.DSTScoring{
+		PointsAllowed: fantasy.Tiers{{Min: 0, Points: 10}, {Min: 1, Points: 7}, {Min: 14, Points: 0}, {Min: 28, Points: -4}},
+		YardsAllowed:  fantasy.Tiers{{Min: 0, Points: 5}, {Min: 250, Points: 0}, {Min: 500, Points: -5}},
+	}
+	var got []Bucket
+	for _, b := range DSTTable(games, s) {
+		got = append(got, *b)
+	}
+	// Points and yards allowed by each defense: NE 42/537, KC 27/371,
+	// Cin 20/268, Bal 0/221, Bal 10/280, Cin 3/150.
+	want := []Bucket{
+		{Label: "0", Min: 0, Games: 1, PointsAllowed: 10, YardsAllowed: 5},
+		{Label: "1-13", Min: 1, Games: 2, PointsAllowed: 7, YardsAllowed: 2.5},
+		{Label: "14-27", Min: 14, Games: 2, PointsAllowed: 0, YardsAllowed: 0},
+		{Label: "28+", Min: 28, Games: 1, PointsAllowed: -4, YardsAllowed: -5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
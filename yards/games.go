@@ -0,0 +1,119 @@
+package yards
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+// Game is one row of a season's game results, in the format of the
+// pro-football-reference.com schedule & results table.
+type Game struct {
+	Week       int
+	Winner     string // canonical team
+	Loser      string // canonical team
+	WinnerAway bool
+
+	WinnerPoints    int
+	LoserPoints     int
+	WinnerYards     int
+	WinnerTurnovers int
+	LoserYards      int
+	LoserTurnovers  int
+}
+
+// Defense is one team's defensive result in a game.
+type Defense struct {
+	Team          string
+	Opponent      string
+	PointsAllowed int
+	YardsAllowed  int
+	Takeaways     int
+}
+
+// Defenses returns the two defensive results for the game, winner
+// first.
+func (g *Game) Defenses() [2]Defense {
+	return [2]Defense{
+		{g.Winner, g.Loser, g.LoserPoints, g.LoserYards, g.LoserTurnovers},
+		{g.Loser, g.Winner, g.WinnerPoints, g.WinnerYards, g.WinnerTurnovers},
+	}
+}
+
+// ReadGames reads the regular season games from one or more game CSV
+// files.  Team names are converted with fantasy.CanonTeam.  Header rows
+// and playoff games, which have a non-numeric week, are skipped, as are
+// games that have not been played yet.
+func ReadGames(filenames ...string) ([]*Game, error) {
+	const (
+		colWeek            = 0
+		colWinner          = 4
+		colAt              = 5
+		colLoser           = 6
+		colWinnerPoints    = 8
+		colLoserPoints     = 9
+		colWinnerYards     = 10
+		colWinnerTurnovers = 11
+		colLoserYards      = 12
+		colLoserTurnovers  = 13
+	)
+	var games []*Game
+	for _, filename := range filenames {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = -1
+		for line := 1; ; line++ {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				f.Close()
+				return nil, err
+			}
+			week, err := strconv.Atoi(record[colWeek])
+			if err != nil || len(record) <= colLoserTurnovers || record[colWinnerPoints] == "" {
+				continue
+			}
+			var stats [6]int
+			for i, col := range []int{colWinnerPoints, colLoserPoints, colWinnerYards, colWinnerTurnovers, colLoserYards, colLoserTurnovers} {
+				stats[i], err = strconv.Atoi(record[col])
+				if err != nil {
+					f.Close()
+					return nil, fmt.Errorf("%s:%d: %s", filename, line, err)
+				}
+			}
+			winner, err := fantasy.CanonTeam(record[colWinner])
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s:%d: %s", filename, line, err)
+			}
+			loser, err := fantasy.CanonTeam(record[colLoser])
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s:%d: %s", filename, line, err)
+			}
+			games = append(games, &Game{
+				Week:            week,
+				Winner:          winner,
+				Loser:           loser,
+				WinnerAway:      record[colAt] == "@",
+				WinnerPoints:    stats[0],
+				LoserPoints:     stats[1],
+				WinnerYards:     stats[2],
+				WinnerTurnovers: stats[3],
+				LoserYards:      stats[4],
+				LoserTurnovers:  stats[5],
+			})
+		}
+		f.Close()
+	}
+	return games, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/yards"
+)
+
+var (
+	scoring = flag.String("scoring", "", "scoring JSON file with DST points and yards allowed tiers")
+	asJSON  = flag.Bool("json", false, "output the collapsed table as points allowed tiers in JSON")
+)
+
+func main() {
+	flag.Parse()
+	if *scoring == "" || flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s -scoring <scoring-json> <games-csv>...\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	s, err := fantasy.ReadScoring(*scoring)
+	if err != nil {
+		log.Fatal(err)
+	}
+	games, err := yards.ReadGames(flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	table := yards.DSTTable(games, &s.DST)
+	if *asJSON {
+		var tiers fantasy.Tiers
+		for _, b := range table {
+			tiers = append(tiers, fantasy.Tier{Min: b.Min, Points: math.Round(b.Expected())})
+		}
+		b, err := json.MarshalIndent(tiers, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s\n", b)
+		return
+	}
+
+	fmt.Printf("%-7s %5s %6s %6s %7s %4s\n", "allowed", "games", "pa", "yds", "total", "pts")
+	for _, b := range table {
+		fmt.Printf("%-7s %5d %+6.2f %+6.2f %+7.2f %+4d\n", b.Label, b.Games, b.PointsAllowed, b.YardsAllowed, b.Expected(), int(math.Round(b.Expected())))
+	}
+}
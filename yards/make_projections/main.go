@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/dbtleonia/fantasy"
+	"github.com/dbtleonia/fantasy/yards"
+)
+
+var (
+	scoring      = flag.String("scoring", "", "scoring JSON file with DST tiers and sack, def_td and safety points")
+	schedule     = flag.String("schedule", "", "schedule CSV for the season to project")
+	prior        = flag.Float64("prior", 8, "number of average games to shrink each team's ratings towards")
+	pointsStddev = flag.Float64("points_stddev", 10, "stddev of points scored by a team in a game")
+	yardsStddev  = flag.Float64("yards_stddev", 65, "stddev of yards gained by a team in a game")
+	kickerShare  = flag.Float64("kicker_share", 0.36, "kicker fantasy points per team point scored")
+	sacks        = flag.Float64("sacks", 2.4, "sacks per team per game")
+	defTDs       = flag.Float64("def_tds_per_takeaway", 0.08, "defensive TDs per interception or fumble recovery")
+	safeties     = flag.Float64("safeties", 0.04, "safeties per team per game")
+)
+
+func main() {
+	flag.Parse()
+	if *scoring == "" || *schedule == "" || flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s -scoring <scoring-json> -schedule <schedule-csv> <games-csv>...\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	s, err := fantasy.ReadScoring(*scoring)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sched, err := fantasy.ReadSchedule(*schedule)
+	if err != nil {
+		log.Fatal(err)
+	}
+	games, err := yards.ReadGames(flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	projections := yards.Project(sched, yards.EstimateStrength(games, *prior), s, &yards.Params{
+		PointsStddev:      *pointsStddev,
+		YardsStddev:       *yardsStddev,
+		KickerShare:       *kickerShare,
+		Sacks:             *sacks,
+		DefTDsPerTakeaway: *defTDs,
+		Safeties:          *safeties,
+	})
+	if len(projections) == 0 {
+		log.Fatal("empty schedule")
+	}
+
+	out := csv.NewWriter(os.Stdout)
+	header := []string{"team", "pos", "points"}
+	for w := 1; w < len(projections[0].Weeks); w++ {
+		header = append(header, fmt.Sprintf("week%d", w))
+	}
+	out.Write(header)
+	for _, p := range projections {
+		record := []string{p.Team, p.Pos, fmt.Sprintf("%.2f", p.Total())}
+		for _, pts := range p.Weeks[1:] {
+			record = append(record, strconv.FormatFloat(pts, 'f', 2, 64))
+		}
+		out.Write(record)
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package yards
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+type Params struct {
+	PointsStddev float64 // of points scored by a team in a game
+	YardsStddev  float64 // of yards gained by a team in a game
+	KickerShare  float64 // kicker fantasy points per team point scored
+
+	// The game results have no sacks, defensive TDs or safeties, so
+	// these are league averages: per team per game for sacks and
+	// safeties, and per takeaway for defensive TDs.
+	Sacks             float64
+	DefTDsPerTakeaway float64
+	Safeties          float64
+}
+
+// Projection is a team's weekly fantasy points at one position.  Weeks
+// is indexed by week number; index 0 and bye weeks are 0.
+type Projection struct {
+	Team  string
+	Pos   string // DST or K
+	Weeks []float64
+}
+
+func (p *Projection) Total() float64 {
+	total := 0.0
+	for _, pts := range p.Weeks {
+		total += pts
+	}
+	return total
+}
+
+// Project returns DST and kicker projections for every team in the
+// schedule under the league scoring, sorted by team then position.
+// Every defense gets the league average sacks and safeties, and
+// defensive TDs in proportion to its expected takeaways.
+func Project(schedule []*fantasy.Game, st *Strength, s *fantasy.Scoring, p *Params) []*Projection {
+	extra := p.Sacks*s.Stats["sack"] + p.Safeties*s.Stats["safety"]
+	perTakeaway := s.DST.Takeaway + p.DefTDsPerTakeaway*s.Stats["def_td"]
+	numWeeks := 0
+	for _, g := range schedule {
+		if g.Week > numWeeks {
+			numWeeks = g.Week
+		}
+	}
+	dst := make(map[string]*Projection)
+	k := make(map[string]*Projection)
+	for _, g := range schedule {
+		for _, m := range [][2]string{{g.Away, g.Home}, {g.Home, g.Away}} {
+			team, opp := m[0], m[1]
+			if dst[team] == nil {
+				dst[team] = &Projection{team, "DST", make([]float64, numWeeks+1)}
+				k[team] = &Projection{team, "K", make([]float64, numWeeks+1)}
+			}
+			allowed, yards, takeaways := st.Matchup(opp, team)
+			dst[team].Weeks[g.Week] += expectedTierPoints(s.DST.PointsAllowed, allowed, p.PointsStddev) +
+				expectedTierPoints(s.DST.YardsAllowed, yards, p.YardsStddev) +
+				takeaways*perTakeaway + extra
+			scored, _, _ := st.Matchup(team, opp)
+			k[team].Weeks[g.Week] += scored * p.KickerShare
+		}
+	}
+
+	var result []*Projection
+	for team := range dst {
+		result = append(result, dst[team], k[team])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Team != result[j].Team {
+			return result[i].Team < result[j].Team
+		}
+		return result[i].Pos < result[j].Pos
+	})
+	return result
+}
+
+// expectedTierPoints returns the expected tier points for an integer
+// value drawn from a normal distribution.  Mass below the first tier
+// counts towards the first tier.
+func expectedTierPoints(ts fantasy.Tiers, mean, stddev float64) float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+	if stddev <= 0 {
+		return ts.Points(int(math.Round(mean)))
+	}
+	cdf := func(x float64) float64 {
+		return 0.5 * (1 + math.Erf((x-mean)/(stddev*math.Sqrt2)))
+	}
+	result := 0.0
+	lo := 0.0
+	for i, t := range ts {
+		hi := 1.0
+		if i+1 < len(ts) {
+			hi = cdf(float64(ts[i+1].Min) - 0.5)
+		}
+		if hi > lo {
+			result += (hi - lo) * t.Points
+			lo = hi
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package yards
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dbtleonia/fantasy"
+)
+
+func TestEstimateStrength(t *testing.T) {
+	games, err := ReadGames("testdata/games.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Per team game: 102 points, 1827 yards and 12 turnovers over 6.
+	s := EstimateStrength(games, 0)
+	if s.Points != 17 || s.Yards != 304.5 || s.Turnovers != 2 {
+		t.Errorf("got averages %v, %v, %v; want 17, 304.5, 2", s.Points, s.Yards, s.Turnovers)
+	}
+	tests := []struct {
+		desc string
+		got  float64
+		want float64
+	}{
+		{"KC offense points", s.Offense["KC"].Points, 42.0 / 17},
+		{"Bal defense points", s.Defense["Bal"].Points, 5.0 / 17},
+		{"Bal defense turnovers", s.Defense["Bal"].Turnovers, 3.5 / 2},
+		{"Bal defense points, shrunk", EstimateStrength(games, 2).Defense["Bal"].Points, (10 + 2*17.0) / (4 * 17)},
+	}
+	for _, test := range tests {
+		if math.Abs(test.got-test.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", test.desc, test.got, test.want)
+		}
+	}
+
+	// No turnovers in the whole league.
+	for _, g := range games {
+		g.WinnerTurnovers, g.LoserTurnovers = 0, 0
+	}
+	if r := EstimateStrength(games, 1).Defense["Bal"].Turnovers; r != 1 {
+		t.Errorf("got turnover rating %v with no turnovers, want 1", r)
+	}
+}
+
+func TestExpectedTierPoints(t *testing.T) {
+	ts := fantasy.Tiers{{Min: 0, Points: 10}, {Min: 7, Points: 4}, {Min: 14, Points: 1}}
+	tests := []struct {
+		mean, stddev, want float64
+	}{
+		{3, 0, 10},
+		{20, 0, 1},
+		{6.5, 1, 7},     // half in each of the first two tiers
+		{-30, 5, 10},    // mass below the first tier counts towards it
+		{100, 5, 1},     // all in the last tier
+		{10.5, 1e-3, 4}, // tiny stddev is like none
+	}
+	for _, test := range tests {
+		if got := expectedTierPoints(ts, test.mean, test.stddev); math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("expectedTierPoints(%v, %v) = %v; want %v", test.mean, test.stddev, got, test.want)
+		}
+	}
+}
+
+func TestProject(t *testing.T) {
+	games, err := ReadGames("testdata/games.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule := []*fantasy.Game{{Week: 2, Away: "KC", Home: "NE"}}
+	s := &fantasy.Scoring{
+		Stats: map[string]float64{"sack": 1, "def_td": 6, "safety": 2},
+		DST:   fantasy.DSTScoring{Takeaway: 2},
+	}
+	p := &Params{Sacks: 2, DefTDsPerTakeaway: 0.1, Safeties: 0.5, KickerShare: 0.5}
+	projections := Project(schedule, EstimateStrength(games, 0), s, p)
+	if len(projections) != 4 {
+		t.Fatalf("got %d projections, want 4", len(projections))
+	}
+	ne := projections[2]
+	if ne.Team != "NE" || ne.Pos != "DST" || len(ne.Weeks) != 3 || ne.Weeks[1] != 0 {
+		t.Fatalf("got %+v, want NE DST for weeks 0-2", ne)
+	}
+	// NE forces 2 * 0.5 * 0.5 takeaways from KC, each worth 2 plus 0.1
+	// TDs.  Sacks and safeties add 2*1 + 0.5*2.
+	if want := 0.5*(2+0.1*6) + 2 + 1; math.Abs(ne.Weeks[2]-want) > 1e-9 {
+		t.Errorf("got NE DST %v, want %v", ne.Weeks[2], want)
+	}
+	kc := projections[1]
+	if want := 0.5 * 42 * 42 / 17; kc.Team != "KC" || kc.Pos != "K" || math.Abs(kc.Total()-want) > 1e-9 {
+		t.Errorf("got %s %s %v, want KC K %v", kc.Team, kc.Pos, kc.Total(), want)
+	}
+}
@@ -0,0 +1,100 @@
+package yards
+
+// Rating is a team's strength relative to the league average, as a
+// multiplier: 1.0 is average.  For an offense, higher means it scores
+// more points, gains more yards and commits more turnovers.  For a
+// defense, higher means it allows more points and yards and forces more
+// turnovers.
+type Rating struct {
+	Points    float64
+	Yards     float64
+	Turnovers float64
+}
+
+type Strength struct {
+	// League averages per team per game.
+	Points    float64
+	Yards     float64
+	Turnovers float64
+
+	Offense map[string]*Rating
+	Defense map[string]*Rating
+}
+
+// EstimateStrength rates each team's offense and defense from past
+// games.  Each rating is shrunk towards average as if the team had
+// played prior extra average games.
+func EstimateStrength(games []*Game, prior float64) *Strength {
+	type totals struct {
+		games                    float64
+		points, yards, turnovers float64
+	}
+	offense := make(map[string]*totals)
+	defense := make(map[string]*totals)
+	var league totals
+	for _, g := range games {
+		for _, d := range g.Defenses() {
+			if offense[d.Opponent] == nil {
+				offense[d.Opponent] = &totals{}
+			}
+			if defense[d.Team] == nil {
+				defense[d.Team] = &totals{}
+			}
+			for _, t := range []*totals{offense[d.Opponent], defense[d.Team], &league} {
+				t.games++
+				t.points += float64(d.PointsAllowed)
+				t.yards += float64(d.YardsAllowed)
+				t.turnovers += float64(d.Takeaways)
+			}
+		}
+	}
+
+	s := &Strength{
+		Offense: make(map[string]*Rating),
+		Defense: make(map[string]*Rating),
+	}
+	if league.games == 0 {
+		return s
+	}
+	s.Points = league.points / league.games
+	s.Yards = league.yards / league.games
+	s.Turnovers = league.turnovers / league.games
+
+	// ratio returns the per game average as a multiple of the league
+	// average, or 1 if the league average is 0.
+	ratio := func(total, games, avg float64) float64 {
+		if avg == 0 {
+			return 1
+		}
+		return (total + prior*avg) / ((games + prior) * avg)
+	}
+	rate := func(t *totals) *Rating {
+		return &Rating{
+			Points:    ratio(t.points, t.games, s.Points),
+			Yards:     ratio(t.yards, t.games, s.Yards),
+			Turnovers: ratio(t.turnovers, t.games, s.Turnovers),
+		}
+	}
+	for team, t := range offense {
+		s.Offense[team] = rate(t)
+	}
+	for team, t := range defense {
+		s.Defense[team] = rate(t)
+	}
+	return s
+}
+
+// Matchup returns the expected points, yards and turnovers for the
+// offense against the defense.  Teams without any past games are
+// treated as average.
+func (s *Strength) Matchup(offense, defense string) (points, yards, turnovers float64) {
+	o, ok := s.Offense[offense]
+	if !ok {
+		o = &Rating{1, 1, 1}
+	}
+	d, ok := s.Defense[defense]
+	if !ok {
+		d = &Rating{1, 1, 1}
+	}
+	return s.Points * o.Points * d.Points, s.Yards * o.Yards * d.Yards, s.Turnovers * o.Turnovers * d.Turnovers
+}